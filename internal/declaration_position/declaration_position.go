@@ -0,0 +1,36 @@
+package declaration_position
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/vphpersson/type_generation/internal/package_files"
+)
+
+// GetDeclarationPosition returns the position of the declaration of the type with the provided name in the source of
+// its package, or token.NoPos if there is no such declaration. The positions of the declarations of one package are
+// ordered by file name and offset.
+func GetDeclarationPosition(pkgPath string, typeName string) (token.Pos, error) {
+	files, err := package_files.GetFiles(pkgPath)
+	if err != nil {
+		return token.NoPos, fmt.Errorf("get files: %w", err)
+	}
+
+	for _, file := range files {
+		for _, topLevelDeclaration := range file.Decls {
+			genericDeclarationNode, ok := topLevelDeclaration.(*ast.GenDecl)
+			if !ok || genericDeclarationNode.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genericDeclarationNode.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == typeName {
+					return typeSpec.Pos(), nil
+				}
+			}
+		}
+	}
+
+	return token.NoPos, nil
+}
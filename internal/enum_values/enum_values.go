@@ -0,0 +1,146 @@
+package enum_values
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	goTypes "go/types"
+	"reflect"
+	"slices"
+	"strconv"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/internal/package_files"
+)
+
+var (
+	ErrNilPackage    = errors.New("nil package")
+	ErrNilScope      = errors.New("nil scope")
+	ErrNotString     = errors.New("not a string")
+	ErrEmptyTypeName = errors.New("empty type name")
+)
+
+func discoverUsingTypesImporter(pkgPath string, typeName string) ([]string, error) {
+	pkg, err := package_files.GetTypesPackage(pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("get types package: %w", err)
+	}
+	if pkg == nil {
+		return nil, motmedelErrors.NewWithTrace(ErrNilPackage)
+	}
+
+	pkgScope := pkg.Scope()
+	if pkgScope == nil {
+		return nil, motmedelErrors.NewWithTrace(ErrNilScope)
+	}
+
+	var constants []*goTypes.Const
+	for _, name := range pkgScope.Names() {
+		constantObject, ok := pkgScope.Lookup(name).(*goTypes.Const)
+		if !ok {
+			continue
+		}
+
+		namedType, ok := constantObject.Type().(*goTypes.Named)
+		if !ok || namedType.Obj().Name() != typeName {
+			continue
+		}
+
+		constants = append(constants, constantObject)
+	}
+
+	// The scope names are sorted alphabetically; restore the declaration order.
+	slices.SortFunc(constants, func(a, b *goTypes.Const) int { return int(a.Pos() - b.Pos()) })
+
+	var values []string
+	for _, c := range constants {
+		if c.Val().Kind() != constant.String {
+			return nil, motmedelErrors.NewWithTrace(ErrNotString, c.Name())
+		}
+		values = append(values, constant.StringVal(c.Val()))
+	}
+
+	return values, nil
+}
+
+func discoverInSource(pkgPath string, typeName string) ([]string, error) {
+	files, err := package_files.GetFiles(pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("get files: %w", err)
+	}
+
+	var values []string
+
+	for _, file := range files {
+		for _, topLevelDeclaration := range file.Decls {
+			genericDeclarationNode, ok := topLevelDeclaration.(*ast.GenDecl)
+			if !ok || genericDeclarationNode.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range genericDeclarationNode.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				// Only constants explicitly declared with the type are considered; an implicitly repeated string
+				// constant would only duplicate the previous value.
+				typeIdentifier, ok := valueSpec.Type.(*ast.Ident)
+				if !ok || typeIdentifier.Name != typeName {
+					continue
+				}
+
+				for _, value := range valueSpec.Values {
+					basicLiteral, ok := value.(*ast.BasicLit)
+					if !ok || basicLiteral.Kind != token.STRING {
+						return nil, motmedelErrors.NewWithTrace(ErrNotString, typeName)
+					}
+
+					unquotedValue, err := strconv.Unquote(basicLiteral.Value)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(
+							fmt.Errorf("strconv unquote: %w", err),
+							basicLiteral.Value,
+						)
+					}
+					values = append(values, unquotedValue)
+				}
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// GetEnumValues returns the values of the string constants declared with the provided named type, in declaration
+// order. No values are returned if the type has no such constants.
+func GetEnumValues(reflectType reflect.Type) ([]string, error) {
+	if reflectType.Kind() != reflect.String {
+		return nil, motmedelErrors.NewWithTrace(ErrNotString)
+	}
+
+	typeName := reflectType.Name()
+	if typeName == "" {
+		return nil, motmedelErrors.NewWithTrace(ErrEmptyTypeName)
+	}
+
+	values, sourceErr := discoverInSource(reflectType.PkgPath(), typeName)
+	if len(values) != 0 {
+		return values, nil
+	}
+
+	values, importerErr := discoverUsingTypesImporter(reflectType.PkgPath(), typeName)
+	if len(values) != 0 {
+		return values, nil
+	}
+
+	// Not finding any values is expected for types that are not enums; only fail if neither source could be read.
+	if sourceErr != nil && importerErr != nil {
+		return nil, errors.Join(sourceErr, importerErr)
+	}
+
+	return nil, nil
+}
@@ -4,13 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/importer"
-	"go/parser"
 	"go/token"
 	goTypes "go/types"
-	"os"
 	"reflect"
+	"sync"
 
+	"github.com/vphpersson/type_generation/internal/package_files"
 	"github.com/vphpersson/type_generation/pkg/types/generic_type_info"
 	"github.com/vphpersson/type_generation/pkg/types/shape"
 
@@ -29,44 +28,76 @@ var (
 	ErrNotGeneric      = errors.New("not a generic type")
 )
 
-func detectShapeTypes(
-	t goTypes.Type,
-	paramSet map[*goTypes.TypeParam]struct{},
-) (*goTypes.TypeParam, shape.Kind, bool) {
-	switch tt := t.(type) {
+// detectShapeTypes returns the shape of the type if it uses any of the type parameters, and nil otherwise.
+func detectShapeTypes(t goTypes.Type, paramSet map[*goTypes.TypeParam]struct{}) *shape.Shape {
+	switch tt := goTypes.Unalias(t).(type) {
 	case *goTypes.TypeParam:
 		if _, ok := paramSet[tt]; ok {
-			return tt, shape.KindDirect, true
+			return &shape.Shape{Kind: shape.KindParam, Param: tt.Obj().Name()}
 		}
-		return nil, 0, false
 	case *goTypes.Pointer:
-		if p, _, ok := detectShapeTypes(tt.Elem(), paramSet); ok {
-			return p, shape.KindPointer, true
+		if elem := detectShapeTypes(tt.Elem(), paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindPointer, Elem: elem}
 		}
 	case *goTypes.Slice:
-		if p, _, ok := detectShapeTypes(tt.Elem(), paramSet); ok {
-			return p, shape.KindSlice, true
+		if elem := detectShapeTypes(tt.Elem(), paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindSlice, Elem: elem}
 		}
 	case *goTypes.Array:
-		if p, _, ok := detectShapeTypes(tt.Elem(), paramSet); ok {
-			return p, shape.KindArray, true
+		if elem := detectShapeTypes(tt.Elem(), paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindArray, Elem: elem}
+		}
+	case *goTypes.Chan:
+		if elem := detectShapeTypes(tt.Elem(), paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindChan, Elem: elem}
 		}
 	case *goTypes.Map:
-		if p, _, ok := detectShapeTypes(tt.Elem(), paramSet); ok {
-			return p, shape.KindMapValue, true
+		key := detectShapeTypes(tt.Key(), paramSet)
+		elem := detectShapeTypes(tt.Elem(), paramSet)
+		if key != nil || elem != nil {
+			return &shape.Shape{Kind: shape.KindMap, Key: key, Elem: elem}
+		}
+	case *goTypes.Signature:
+		var params, results []*shape.Shape
+		used := false
+		for i := range tt.Params().Len() {
+			param := detectShapeTypes(tt.Params().At(i).Type(), paramSet)
+			used = used || param != nil
+			params = append(params, param)
+		}
+		for i := range tt.Results().Len() {
+			result := detectShapeTypes(tt.Results().At(i).Type(), paramSet)
+			used = used || result != nil
+			results = append(results, result)
+		}
+		if used {
+			return &shape.Shape{Kind: shape.KindFunc, Params: params, Results: results}
 		}
-		if p, _, ok := detectShapeTypes(tt.Key(), paramSet); ok {
-			return p, shape.KindMapKey, true
+	case *goTypes.Named:
+		typeArguments := tt.TypeArgs()
+		if typeArguments == nil {
+			return nil
+		}
+
+		var typeArgumentShapes []*shape.Shape
+		used := false
+		for i := range typeArguments.Len() {
+			typeArgumentShape := detectShapeTypes(typeArguments.At(i), paramSet)
+			used = used || typeArgumentShape != nil
+			typeArgumentShapes = append(typeArgumentShapes, typeArgumentShape)
+		}
+		if used {
+			return &shape.Shape{Kind: shape.KindGeneric, TypeArguments: typeArgumentShapes}
 		}
 	}
 
-	return nil, 0, false
+	return nil
 }
 
 func discoverUsingTypesImporter(pkgPath string, typeName string) (*generic_type_info.GenericTypeInfo, error) {
-	pkg, err := importer.Default().Import(pkgPath)
+	pkg, err := package_files.GetTypesPackage(pkgPath)
 	if err != nil {
-		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("go importer default import: %w", err))
+		return nil, fmt.Errorf("get types package: %w", err)
 	}
 	if pkg == nil {
 		return nil, motmedelErrors.NewWithTrace(ErrNilPackage)
@@ -110,137 +141,164 @@ func discoverUsingTypesImporter(pkgPath string, typeName string) (*generic_type_
 		parameterNames[i] = typeParameter.Obj().Name()
 	}
 
-	fieldNameToShape := map[string]shape.Shape{}
-	paramToField := map[string]string{}
+	fieldNameToShape := map[string]*shape.Shape{}
 	for i := range structType.NumFields() {
 		field := structType.Field(i)
-		typeParameter, kind, ok := detectShapeTypes(field.Type(), parameterNamesSet)
-		if !ok {
-			continue
-		}
-
-		name := field.Name()
-		fieldShape := shape.Shape{Param: typeParameter.Obj().Name(), Kind: kind}
-		fieldNameToShape[name] = fieldShape
-		if _, exists := paramToField[fieldShape.Param]; !exists {
-			paramToField[fieldShape.Param] = name
+		if fieldShape := detectShapeTypes(field.Type(), parameterNamesSet); fieldShape != nil {
+			fieldNameToShape[field.Name()] = fieldShape
 		}
 	}
 
 	return &generic_type_info.GenericTypeInfo{
-		TypeParameterNames:           parameterNames,
-		FieldNameToShape:             fieldNameToShape,
-		TypeParameterNameToFieldName: paramToField,
+		TypeParameterNames: parameterNames,
+		FieldNameToShape:   fieldNameToShape,
 	}, nil
 }
 
-func detectShapeAst(e ast.Expr, paramSet map[string]struct{}) (string, shape.Kind, bool) {
+// detectShapeAstFields returns the shapes of the types of a field list, with one shape per name, and whether any of
+// them uses any of the type parameters.
+func detectShapeAstFields(fieldList *ast.FieldList, paramSet map[string]struct{}) ([]*shape.Shape, bool) {
+	if fieldList == nil {
+		return nil, false
+	}
+
+	var shapes []*shape.Shape
+	used := false
+	for _, field := range fieldList.List {
+		fieldShape := detectShapeAst(field.Type, paramSet)
+		used = used || fieldShape != nil
+		for range max(len(field.Names), 1) {
+			shapes = append(shapes, fieldShape)
+		}
+	}
+
+	return shapes, used
+}
+
+// detectShapeAst returns the shape of the type expression if it uses any of the type parameters, and nil otherwise.
+func detectShapeAst(e ast.Expr, paramSet map[string]struct{}) *shape.Shape {
 	switch ee := e.(type) {
 	case *ast.Ident:
 		if _, ok := paramSet[ee.Name]; ok {
-			return ee.Name, shape.KindDirect, true
+			return &shape.Shape{Kind: shape.KindParam, Param: ee.Name}
 		}
+	case *ast.ParenExpr:
+		return detectShapeAst(ee.X, paramSet)
 	case *ast.StarExpr:
-		if p, _, ok := detectShapeAst(ee.X, paramSet); ok {
-			return p, shape.KindPointer, true
+		if elem := detectShapeAst(ee.X, paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindPointer, Elem: elem}
 		}
 	case *ast.ArrayType:
-		if p, _, ok := detectShapeAst(ee.Elt, paramSet); ok {
+		if elem := detectShapeAst(ee.Elt, paramSet); elem != nil {
 			if ee.Len == nil {
-				return p, shape.KindSlice, true
+				return &shape.Shape{Kind: shape.KindSlice, Elem: elem}
 			}
-			return p, shape.KindArray, true
+			return &shape.Shape{Kind: shape.KindArray, Elem: elem}
+		}
+	case *ast.Ellipsis:
+		// A variadic parameter, whose type is a slice.
+		if elem := detectShapeAst(ee.Elt, paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindSlice, Elem: elem}
+		}
+	case *ast.ChanType:
+		if elem := detectShapeAst(ee.Value, paramSet); elem != nil {
+			return &shape.Shape{Kind: shape.KindChan, Elem: elem}
 		}
 	case *ast.MapType:
-		if p, _, ok := detectShapeAst(ee.Value, paramSet); ok {
-			return p, shape.KindMapValue, true
+		key := detectShapeAst(ee.Key, paramSet)
+		elem := detectShapeAst(ee.Value, paramSet)
+		if key != nil || elem != nil {
+			return &shape.Shape{Kind: shape.KindMap, Key: key, Elem: elem}
+		}
+	case *ast.FuncType:
+		params, paramsUsed := detectShapeAstFields(ee.Params, paramSet)
+		results, resultsUsed := detectShapeAstFields(ee.Results, paramSet)
+		if paramsUsed || resultsUsed {
+			return &shape.Shape{Kind: shape.KindFunc, Params: params, Results: results}
+		}
+	case *ast.IndexExpr:
+		if typeArgument := detectShapeAst(ee.Index, paramSet); typeArgument != nil {
+			return &shape.Shape{Kind: shape.KindGeneric, TypeArguments: []*shape.Shape{typeArgument}}
 		}
-		if p, _, ok := detectShapeAst(ee.Key, paramSet); ok {
-			return p, shape.KindMapKey, true
+	case *ast.IndexListExpr:
+		var typeArguments []*shape.Shape
+		used := false
+		for _, index := range ee.Indices {
+			typeArgument := detectShapeAst(index, paramSet)
+			used = used || typeArgument != nil
+			typeArguments = append(typeArguments, typeArgument)
+		}
+		if used {
+			return &shape.Shape{Kind: shape.KindGeneric, TypeArguments: typeArguments}
 		}
 	}
 
-	return "", 0, false
+	return nil
 }
 
-func discoverInWorkingDir(typeName string) (*generic_type_info.GenericTypeInfo, error) {
-	workingDirectoryPath, err := os.Getwd()
+func discoverInSource(pkgPath string, typeName string) (*generic_type_info.GenericTypeInfo, error) {
+	files, err := package_files.GetFiles(pkgPath)
 	if err != nil {
-		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("os getwd: %w", err))
+		return nil, fmt.Errorf("get files: %w", err)
 	}
 
-	packages, err := parser.ParseDir(token.NewFileSet(), workingDirectoryPath, nil, 0)
-	if err != nil {
-		return nil, motmedelErrors.NewWithTrace(
-			fmt.Errorf("go parser parse dir: %w", err),
-			workingDirectoryPath,
-		)
-	}
+	for _, file := range files {
+		for _, topLevelDeclaration := range file.Decls {
+			genericDeclarationNode, ok := topLevelDeclaration.(*ast.GenDecl)
+			if !ok || genericDeclarationNode.Tok != token.TYPE {
+				continue
+			}
 
-	for _, pkg := range packages {
-		for _, file := range pkg.Files {
-			for _, topLevelDeclaration := range file.Decls {
-				genericDeclarationNode, ok := topLevelDeclaration.(*ast.GenDecl)
-				if !ok || genericDeclarationNode.Tok != token.TYPE {
+			for _, spec := range genericDeclarationNode.Specs {
+				// Find the type spec for the base type.
+
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name == nil || typeSpec.Name.Name != typeName {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
 					continue
 				}
 
-				for _, spec := range genericDeclarationNode.Specs {
-					// Find the type spec for the base type.
+				// Extract the type parameters
 
-					typeSpec, ok := spec.(*ast.TypeSpec)
-					if !ok || typeSpec.Name == nil || typeSpec.Name.Name != typeName {
-						continue
+				var paramNames []string
+				paramSet := map[string]struct{}{}
+				if typeParams := typeSpec.TypeParams; typeParams != nil {
+					for _, field := range typeParams.List {
+						for _, identifier := range field.Names {
+							paramNames = append(paramNames, identifier.Name)
+							paramSet[identifier.Name] = struct{}{}
+						}
 					}
-					structType, ok := typeSpec.Type.(*ast.StructType)
-					if !ok {
+				}
+				// TODO: No parameters? Can this happen?
+				if len(paramNames) == 0 {
+					continue
+				}
+
+				fieldShapes := map[string]*shape.Shape{}
+				for _, field := range structType.Fields.List {
+					if len(field.Names) == 0 {
 						continue
 					}
 
-					// Extract the type parameters
-
-					var paramNames []string
-					paramSet := map[string]struct{}{}
-					if typeParams := typeSpec.TypeParams; typeParams != nil {
-						for _, field := range typeParams.List {
-							for _, identifier := range field.Names {
-								paramNames = append(paramNames, identifier.Name)
-								paramSet[identifier.Name] = struct{}{}
-							}
-						}
-					}
-					// TODO: No parameters? Can this happen?
-					if len(paramNames) == 0 {
+					// Check if the struct field's type uses any of the type parameters.
+					fieldShape := detectShapeAst(field.Type, paramSet)
+					if fieldShape == nil {
 						continue
 					}
 
-					fieldShapes := map[string]shape.Shape{}
-					paramToField := map[string]string{}
-					for _, field := range structType.Fields.List {
-						if len(field.Names) == 0 {
-							continue
-						}
-
-						// Check if the struct field's type uses any of the type parameters.
-						param, kind, ok := detectShapeAst(field.Type, paramSet)
-						if !ok {
-							continue
-						}
-
-						for _, identifier := range field.Names {
-							fieldShapes[identifier.Name] = shape.Shape{Param: param, Kind: kind}
-							if _, exists := paramToField[param]; !exists {
-								paramToField[param] = identifier.Name
-							}
-						}
+					for _, identifier := range field.Names {
+						fieldShapes[identifier.Name] = fieldShape
 					}
-
-					return &generic_type_info.GenericTypeInfo{
-						TypeParameterNames:           paramNames,
-						FieldNameToShape:             fieldShapes,
-						TypeParameterNameToFieldName: paramToField,
-					}, nil
 				}
+
+				return &generic_type_info.GenericTypeInfo{
+					TypeParameterNames: paramNames,
+					FieldNameToShape:   fieldShapes,
+				}, nil
 			}
 		}
 	}
@@ -248,6 +306,39 @@ func discoverInWorkingDir(typeName string) (*generic_type_info.GenericTypeInfo,
 	return nil, nil
 }
 
+type cacheKey struct {
+	pkgPath  string
+	typeName string
+}
+
+type cacheEntry struct {
+	once            sync.Once
+	genericTypeInfo *generic_type_info.GenericTypeInfo
+	err             error
+}
+
+var (
+	cacheMutex sync.Mutex
+	cache      = map[cacheKey]*cacheEntry{}
+)
+
+func discover(pkgPath string, typeName string) (*generic_type_info.GenericTypeInfo, error) {
+	genericTypeInfo, sourceErr := discoverInSource(pkgPath, typeName)
+	if genericTypeInfo != nil {
+		return genericTypeInfo, nil
+	}
+
+	genericTypeInfo, importerErr := discoverUsingTypesImporter(pkgPath, typeName)
+	if genericTypeInfo != nil {
+		return genericTypeInfo, nil
+	}
+
+	return nil, errors.Join(sourceErr, importerErr)
+}
+
+// GetGenericTypeInfo returns the generic type info of the generic type of which the provided struct type is an
+// instantiation. The info of each generic type is discovered once per process and shared by all its instantiations,
+// and must thus not be modified.
 func GetGenericTypeInfo(structType reflect.Type) (*generic_type_info.GenericTypeInfo, error) {
 	structType = motmedelReflect.RemoveIndirection(structType)
 	if structType.Kind() != reflect.Struct {
@@ -262,19 +353,19 @@ func GetGenericTypeInfo(structType reflect.Type) (*generic_type_info.GenericType
 		return nil, motmedelErrors.NewWithTrace(ErrNotGeneric)
 	}
 
-	var genericTypeInfo *generic_type_info.GenericTypeInfo
+	key := cacheKey{pkgPath: structType.PkgPath(), typeName: typeName}
 
-	var workingDirErr error
-	var importerErr error
-	genericTypeInfo, workingDirErr = discoverInWorkingDir(typeName)
-
-	if genericTypeInfo == nil {
-		genericTypeInfo, importerErr = discoverUsingTypesImporter(structType.PkgPath(), typeName)
+	cacheMutex.Lock()
+	entry, ok := cache[key]
+	if !ok {
+		entry = &cacheEntry{}
+		cache[key] = entry
 	}
+	cacheMutex.Unlock()
 
-	if genericTypeInfo == nil {
-		return nil, errors.Join(workingDirErr, importerErr)
-	}
+	entry.once.Do(func() {
+		entry.genericTypeInfo, entry.err = discover(key.pkgPath, key.typeName)
+	})
 
-	return genericTypeInfo, nil
+	return entry.genericTypeInfo, entry.err
 }
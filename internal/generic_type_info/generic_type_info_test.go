@@ -0,0 +1,64 @@
+package generic_type_info
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vphpersson/type_generation/internal/package_files"
+)
+
+// benchmarkType is an instantiation of a generic struct type of the standard library, whose source is located without
+// regard to the module of the working directory.
+var benchmarkType = reflect.TypeFor[atomic.Pointer[int]]()
+
+func resetCache() {
+	cacheMutex.Lock()
+	cache = map[cacheKey]*cacheEntry{}
+	cacheMutex.Unlock()
+
+	package_files.ResetCache()
+}
+
+func TestGetGenericTypeInfo(t *testing.T) {
+	genericTypeInfo, err := GetGenericTypeInfo(benchmarkType)
+	if err != nil {
+		t.Fatalf("get generic type info: %v", err)
+	}
+	if genericTypeInfo == nil {
+		t.Fatal("no generic type info")
+	}
+	if !reflect.DeepEqual(genericTypeInfo.TypeParameterNames, []string{"T"}) {
+		t.Errorf("type parameter names: got %v, want [T]", genericTypeInfo.TypeParameterNames)
+	}
+
+	cachedGenericTypeInfo, err := GetGenericTypeInfo(benchmarkType)
+	if err != nil {
+		t.Fatalf("get generic type info: %v", err)
+	}
+	if cachedGenericTypeInfo != genericTypeInfo {
+		t.Error("the generic type info was discovered anew")
+	}
+}
+
+func BenchmarkGetGenericTypeInfoCold(b *testing.B) {
+	for b.Loop() {
+		resetCache()
+		if _, err := GetGenericTypeInfo(benchmarkType); err != nil {
+			b.Fatalf("get generic type info: %v", err)
+		}
+	}
+}
+
+func BenchmarkGetGenericTypeInfoWarm(b *testing.B) {
+	resetCache()
+	if _, err := GetGenericTypeInfo(benchmarkType); err != nil {
+		b.Fatalf("get generic type info: %v", err)
+	}
+
+	for b.Loop() {
+		if _, err := GetGenericTypeInfo(benchmarkType); err != nil {
+			b.Fatalf("get generic type info: %v", err)
+		}
+	}
+}
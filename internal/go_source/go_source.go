@@ -0,0 +1,100 @@
+package go_source
+
+import (
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
+)
+
+// ExportedIdentifier returns an exported Go identifier for the name, e.g. "user_id" -> "UserId", or an empty string
+// if the name has no letters or digits.
+func ExportedIdentifier(name string) string {
+	var builder strings.Builder
+	for _, word := range naming_convention.Words(name) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+
+	identifier := builder.String()
+	if identifier != "" && unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+
+	return identifier
+}
+
+// MakeUniqueName returns the name, with a number suffix if it is already used, and marks it as used.
+func MakeUniqueName(base string, usedNames map[string]struct{}) string {
+	name := base
+	for i := 2; ; i++ {
+		if _, ok := usedNames[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+
+	usedNames[name] = struct{}{}
+	return name
+}
+
+// StructTag renders the struct tag with the provided key-value pairs, as a raw string literal unless a value contains
+// a backquote.
+func StructTag(pairs ...[2]string) string {
+	var parts []string
+	for _, pair := range pairs {
+		parts = append(parts, pair[0]+":"+strconv.Quote(pair[1]))
+	}
+
+	tag := strings.Join(parts, " ")
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// Render renders a formatted Go source file of the package with the imports and the declarations.
+func Render(packageName string, imports map[string]struct{}, declarations []string) (string, error) {
+	var builder strings.Builder
+	builder.WriteString("package " + packageName + "\n\n")
+
+	if len(imports) > 0 {
+		// The packages of the standard library, whose paths have no domain, are grouped before the others.
+		var standardImportPaths, otherImportPaths []string
+		for _, importPath := range slices.Sorted(maps.Keys(imports)) {
+			if firstElement, _, _ := strings.Cut(importPath, "/"); strings.Contains(firstElement, ".") {
+				otherImportPaths = append(otherImportPaths, importPath)
+			} else {
+				standardImportPaths = append(standardImportPaths, importPath)
+			}
+		}
+
+		builder.WriteString("import (\n")
+		for i, importPaths := range [][]string{standardImportPaths, otherImportPaths} {
+			if i > 0 && len(importPaths) > 0 && len(standardImportPaths) > 0 {
+				builder.WriteString("\n")
+			}
+			for _, importPath := range importPaths {
+				builder.WriteString("\t" + strconv.Quote(importPath) + "\n")
+			}
+		}
+		builder.WriteString(")\n\n")
+	}
+
+	builder.WriteString(strings.Join(declarations, "\n\n"))
+	builder.WriteString("\n")
+
+	source, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("format source: %w", err), builder.String())
+	}
+
+	return string(source), nil
+}
@@ -0,0 +1,223 @@
+package package_files
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	goTypes "go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+)
+
+var (
+	ErrNoModulePath    = errors.New("no module path")
+	ErrPackageNotFound = errors.New("package not found")
+)
+
+// mainPkgPath is the package path of types declared in the main package, which is located by the package path
+// recorded in the build information of the running program.
+const mainPkgPath = "main"
+
+// commandLineArgumentsPkgPath is the package path recorded for a main package built from files listed on the command
+// line, as with `go run main.go`.
+const commandLineArgumentsPkgPath = "command-line-arguments"
+
+type cacheEntry struct {
+	once  sync.Once
+	files []*ast.File
+	err   error
+}
+
+type typesPackageCacheEntry struct {
+	once         sync.Once
+	typesPackage *goTypes.Package
+	err          error
+}
+
+var (
+	cacheMutex sync.Mutex
+	cache      = map[string]*cacheEntry{}
+
+	typesPackageCacheMutex sync.Mutex
+	typesPackageCache      = map[string]*typesPackageCacheEntry{}
+	// Importers are not safe for concurrent use.
+	typesImporterMutex sync.Mutex
+	typesImporter      = importer.Default()
+)
+
+// findModule returns the root directory and the module path of the module containing the directory, if any.
+func findModule(directoryPath string) (string, string, error) {
+	for {
+		data, err := os.ReadFile(filepath.Join(directoryPath, "go.mod"))
+		if err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				if modulePath, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module"); ok {
+					return directoryPath, strings.Trim(strings.TrimSpace(modulePath), `"`), nil
+				}
+			}
+			return "", "", motmedelErrors.NewWithTrace(ErrNoModulePath, directoryPath)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", "", motmedelErrors.NewWithTrace(fmt.Errorf("os read file: %w", err), directoryPath)
+		}
+
+		parentDirectoryPath := filepath.Dir(directoryPath)
+		if parentDirectoryPath == directoryPath {
+			return "", "", nil
+		}
+		directoryPath = parentDirectoryPath
+	}
+}
+
+// getMainPkgPath returns the package path of the main package of the running program. No path is returned if the
+// program was built from files listed on the command line, which are assumed to be in the working directory.
+func getMainPkgPath() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok || buildInfo.Path == commandLineArgumentsPkgPath {
+		return ""
+	}
+
+	// The main package of a test binary is the tested package with a ".test" suffix.
+	return strings.TrimSuffix(buildInfo.Path, ".test")
+}
+
+// locate returns the directory of the package with the provided path, preferring the main module of the working
+// directory, and otherwise asking the go command, which knows the dependencies of the module and the standard
+// library.
+func locate(pkgPath string) (string, error) {
+	workingDirectoryPath, err := os.Getwd()
+	if err != nil {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("os getwd: %w", err))
+	}
+
+	if pkgPath == mainPkgPath {
+		pkgPath = getMainPkgPath()
+		if pkgPath == "" {
+			return workingDirectoryPath, nil
+		}
+	}
+
+	moduleRootPath, modulePath, err := findModule(workingDirectoryPath)
+	if err != nil {
+		return "", fmt.Errorf("find module: %w", err)
+	}
+	if modulePath != "" {
+		if relativePath, ok := strings.CutPrefix(pkgPath, modulePath); ok &&
+			(relativePath == "" || strings.HasPrefix(relativePath, "/")) {
+			return filepath.Join(moduleRootPath, filepath.FromSlash(relativePath)), nil
+		}
+	}
+
+	command := exec.Command("go", "list", "-find", "-f", "{{.Dir}}", "--", pkgPath)
+	command.Dir = workingDirectoryPath
+	output, err := command.Output()
+	if err != nil {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("%w: go list: %w", ErrPackageNotFound, err), pkgPath)
+	}
+
+	directoryPath := strings.TrimSpace(string(output))
+	if directoryPath == "" {
+		return "", motmedelErrors.NewWithTrace(ErrPackageNotFound, pkgPath)
+	}
+
+	return directoryPath, nil
+}
+
+func parse(pkgPath string) ([]*ast.File, error) {
+	directoryPath, err := locate(pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("locate: %w", err)
+	}
+
+	// Only the files that are part of a build of the package are considered, thus excluding test files and files
+	// excluded by build constraints, which could declare conflicting types.
+	buildPackage, err := build.ImportDir(directoryPath, 0)
+	if err != nil {
+		var noGoError *build.NoGoError
+		if errors.As(err, &noGoError) {
+			err = fmt.Errorf("%w: %w", ErrPackageNotFound, err)
+		}
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("go build import dir: %w", err), directoryPath)
+	}
+
+	fileSet := token.NewFileSet()
+	var files []*ast.File
+	for _, fileName := range buildPackage.GoFiles {
+		filePath := filepath.Join(directoryPath, fileName)
+		file, err := parser.ParseFile(fileSet, filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("go parser parse file: %w", err), filePath)
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// GetFiles returns the parsed source files of the package with the provided path, located in the module of the
+// working directory, its dependencies or the standard library. The files of each package are parsed once per
+// process.
+func GetFiles(pkgPath string) ([]*ast.File, error) {
+	cacheMutex.Lock()
+	entry, ok := cache[pkgPath]
+	if !ok {
+		entry = &cacheEntry{}
+		cache[pkgPath] = entry
+	}
+	cacheMutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.files, entry.err = parse(pkgPath)
+	})
+
+	return entry.files, entry.err
+}
+
+// GetTypesPackage returns the type-checked package with the provided path, imported from the export data of the
+// compiler. Each package is imported once per process.
+func GetTypesPackage(pkgPath string) (*goTypes.Package, error) {
+	typesPackageCacheMutex.Lock()
+	entry, ok := typesPackageCache[pkgPath]
+	if !ok {
+		entry = &typesPackageCacheEntry{}
+		typesPackageCache[pkgPath] = entry
+	}
+	typesPackageCacheMutex.Unlock()
+
+	entry.once.Do(func() {
+		typesImporterMutex.Lock()
+		defer typesImporterMutex.Unlock()
+
+		entry.typesPackage, entry.err = typesImporter.Import(pkgPath)
+		if entry.err != nil {
+			entry.err = motmedelErrors.NewWithTrace(fmt.Errorf("go importer import: %w", entry.err), pkgPath)
+		}
+	})
+
+	return entry.typesPackage, entry.err
+}
+
+// ResetCache discards the parsed files and the imported packages, which are then parsed and imported anew on their
+// next use.
+func ResetCache() {
+	cacheMutex.Lock()
+	cache = map[string]*cacheEntry{}
+	cacheMutex.Unlock()
+
+	typesPackageCacheMutex.Lock()
+	typesPackageCache = map[string]*typesPackageCacheEntry{}
+	typesPackageCacheMutex.Unlock()
+}
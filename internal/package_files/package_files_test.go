@@ -0,0 +1,31 @@
+package package_files
+
+import (
+	"testing"
+)
+
+// benchmarkPkgPath is the path of a standard library package, which is located without regard to the module of the
+// working directory.
+const benchmarkPkgPath = "sync/atomic"
+
+func BenchmarkGetFilesCold(b *testing.B) {
+	for b.Loop() {
+		ResetCache()
+		if _, err := GetFiles(benchmarkPkgPath); err != nil {
+			b.Fatalf("get files: %v", err)
+		}
+	}
+}
+
+func BenchmarkGetFilesWarm(b *testing.B) {
+	ResetCache()
+	if _, err := GetFiles(benchmarkPkgPath); err != nil {
+		b.Fatalf("get files: %v", err)
+	}
+
+	for b.Loop() {
+		if _, err := GetFiles(benchmarkPkgPath); err != nil {
+			b.Fatalf("get files: %v", err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package round_trip
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+type Circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius" jsonschema:"radius,exclusiveminimum:0,multipleof:0.5"`
+}
+
+type Square struct {
+	Kind string `json:"kind"`
+	Side int    `json:"side" jsonschema:"side,minimum:1,maximum:10,default:3,examples:[1,2]"`
+}
+
+// Shape is one of Circle, Square, discriminated by the "kind" property.
+type Shape interface {
+	isShape()
+}
+
+type Inner struct {
+	X string `json:"x" jsonschema:"x,pattern:^<a>&$,enum:[a,'b,c'],description:'the user''s name'"`
+}
+
+type T struct {
+	_        struct{}       `jsonschema:",additionalproperties:true"`
+	B        string         `json:"B"`
+	A        *string        `json:"A"`
+	I        Inner          `json:"i" jsonschema:"i,description:hello,deprecated"`
+	P        *Inner         `json:"P"`
+	S        []Inner        `json:"s" jsonschema:"s,minitems:2,uniqueitems"`
+	M        map[string]int `json:"M"`
+	Bytes    []byte         `json:"Bytes"`
+	At       time.Time      `json:"At"`
+	Any      any            `json:"Any"`
+	Shape    Shape          `json:"Shape"`
+	Shapes   []Shape        `json:"Shapes"`
+	Const    *string        `json:"const" jsonschema:"const,const:fixed,title:Fixed"`
+	Email    string         `json:"email" jsonschema:"email,format:email,readonly"`
+	Optional string         `json:"Optional,omitempty"`
+	OptPtr   *Inner         `json:"optPtr,omitempty"`
+	Empty    string         `json:"empty" jsonschema:"empty,minlength:0,maxlength:3"`
+	Nums     []float64      `json:"nums,omitempty"`
+	OptNull  *int           `json:"optNull" jsonschema:"optNull,optional"`
+}
+
+func (Circle) isShape() {}
+
+func (Square) isShape() {}
+
+// RegisterUnions registers the unions with the context, so that its producers represent them as unions of their
+// variants.
+func RegisterUnions(c *context.Context) error {
+	if _, err := c.RegisterUnion(
+		reflect.TypeFor[Shape](),
+		"kind",
+		map[string]reflect.Type{
+			"circle": reflect.TypeFor[Circle](),
+			"square": reflect.TypeFor[Square](),
+		},
+	); err != nil {
+		return fmt.Errorf("register union (Shape): %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/consumers/jsonschema/types"
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/schema"
+)
+
+// Convert generates Go declarations, in a package with the provided name, from a draft 2020-12 or draft-07 JSON
+// Schema document. The tags of the declarations make the JSON Schema producer, with the strict options, generate an
+// equivalent schema, given that the unions are registered with the generated RegisterUnions function. Arrays whose
+// items are described by position, with `prefixItems` or the array form of `items`, are not supported.
+func Convert(data []byte, packageName string) (string, error) {
+	var document schema.Schema
+	if err := json.Unmarshal(data, &document); err != nil {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (document): %w", err), data)
+	}
+
+	consumerContext := types.Context{PackageName: packageName, Options: jsonschemaTypes.StrictOptions()}
+	output, err := consumerContext.Render(&document)
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("render: %w", err), document)
+	}
+
+	return output, nil
+}
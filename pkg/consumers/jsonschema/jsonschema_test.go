@@ -0,0 +1,208 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vphpersson/type_generation/pkg/consumers/jsonschema"
+	"github.com/vphpersson/type_generation/pkg/consumers/jsonschema/internal/round_trip"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+var update = flag.Bool("update", false, "update the generated round trip package")
+
+// roundTripPath is the path of the source generated from the schema of T, whose declarations are converted back.
+var roundTripPath = filepath.Join("internal", "round_trip", "round_trip.go")
+
+type Shape interface{ isShape() }
+
+type Circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius" jsonschema:"radius,exclusiveminimum:0,multipleof:0.5"`
+}
+
+func (Circle) isShape() {}
+
+type Square struct {
+	Kind string `json:"kind"`
+	Side int    `json:"side" jsonschema:"side,minimum:1,maximum:10,default:3,examples:[1,2]"`
+}
+
+func (Square) isShape() {}
+
+type Inner struct {
+	X string `jsonschema:"x,pattern:'^<a>&$',enum:[a,'b,c'],description:the user's name"`
+}
+
+type T struct {
+	_        struct{} `jsonschema:",additionalproperties:true"`
+	B        string
+	A        *string
+	I        Inner `jsonschema:"i,description:hello,deprecated"`
+	P        *Inner
+	S        []Inner `jsonschema:"s,minitems:2,uniqueitems"`
+	M        map[string]int
+	Bytes    []byte
+	At       time.Time
+	Any      any
+	Shape    Shape
+	Shapes   []Shape
+	Const    *string   `jsonschema:"const,const:fixed,title:Fixed"`
+	Email    string    `jsonschema:"email,format:email,readonly"`
+	Optional string    `json:",omitempty"`
+	OptPtr   *Inner    `json:"optPtr,omitempty"`
+	Empty    string    `jsonschema:"empty,minlength:0,maxlength:3"`
+	Nums     []float64 `json:"nums,omitempty"`
+	OptNull  *int      `jsonschema:"optNull,optional"`
+}
+
+// render renders the schema of the root type, with the strict options and the draft, after registering the unions.
+func render(
+	t *testing.T,
+	draft jsonschemaTypes.Draft,
+	registerUnions func(*typeGenerationContext.Context) error,
+	root reflect.Type,
+) string {
+	t.Helper()
+
+	jsonschemaContext := jsonschemaTypes.Context{
+		Context: typeGenerationContext.New(),
+		Options: jsonschemaTypes.StrictOptions(),
+		Draft:   draft,
+	}
+	if err := registerUnions(jsonschemaContext.Context); err != nil {
+		t.Fatalf("register unions: %v", err)
+	}
+	if err := jsonschemaContext.Add(root); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	document, err := jsonschemaContext.RenderRoot(root)
+	if err != nil {
+		t.Fatalf("render root: %v", err)
+	}
+
+	return document
+}
+
+func registerUnions(c *typeGenerationContext.Context) error {
+	_, err := c.RegisterUnion(
+		reflect.TypeFor[Shape](),
+		"kind",
+		map[string]reflect.Type{"circle": reflect.TypeFor[Circle](), "square": reflect.TypeFor[Square]()},
+	)
+	return err
+}
+
+// TestConvertRoundTrip converts the schema of Go declarations to Go declarations, and checks that their schema is the
+// same.
+func TestConvertRoundTrip(t *testing.T) {
+	for name, draft := range map[string]jsonschemaTypes.Draft{
+		"2020-12":  jsonschemaTypes.Draft202012,
+		"draft-07": jsonschemaTypes.Draft07,
+	} {
+		t.Run(name, func(t *testing.T) {
+			document := render(t, draft, registerUnions, reflect.TypeFor[T]())
+
+			source, err := jsonschema.Convert([]byte(document), "round_trip")
+			if err != nil {
+				t.Fatalf("convert: %v", err)
+			}
+
+			if *update {
+				if err := os.WriteFile(roundTripPath, []byte(source), 0o644); err != nil {
+					t.Fatalf("os write file: %v", err)
+				}
+			}
+
+			expectedSource, err := os.ReadFile(roundTripPath)
+			if err != nil {
+				t.Fatalf("os read file: %v", err)
+			}
+			if source != string(expectedSource) {
+				t.Fatalf("the source differs from %s; run the test with -update:\n%s", roundTripPath, source)
+			}
+
+			roundTripDocument := render(t, draft, round_trip.RegisterUnions, reflect.TypeFor[round_trip.T]())
+			if roundTripDocument != document {
+				t.Errorf("the schema differs after the round trip:\n%s\n%s", document, roundTripDocument)
+			}
+		})
+	}
+}
+
+func TestConvertItemsArray(t *testing.T) {
+	document := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title": "Pair",
+		"type": "object",
+		"properties": {
+			"pair": {"type": "array", "items": [{"type": "string"}], "additionalItems": {"type": "integer"}}
+		}
+	}`
+
+	_, err := jsonschema.Convert([]byte(document), "pair")
+	if !errors.Is(err, typeGenerationErrors.ErrUnsupportedKeyword) {
+		t.Errorf("got %v, want %v", err, typeGenerationErrors.ErrUnsupportedKeyword)
+	}
+}
+
+func TestConvertRootReference(t *testing.T) {
+	document := `{
+		"title": "Node",
+		"type": "object",
+		"properties": {
+			"value": {"type": "integer"},
+			"next": {"anyOf": [{"$ref": "#"}, {"type": "null"}]},
+			"children": {"type": "array", "items": {"$ref": "#/"}}
+		},
+		"required": ["value", "next", "children"]
+	}`
+
+	source, err := jsonschema.Convert([]byte(document), "node")
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	// The fields are compared regardless of their alignment.
+	normalizedSource := strings.Join(strings.Fields(source), " ")
+	for _, field := range []string{"Next *Node", "Children []Node"} {
+		if !strings.Contains(normalizedSource, field) {
+			t.Errorf("got %s, want the field %s", source, field)
+		}
+	}
+}
+
+func TestConvertUnsupportedPropertyName(t *testing.T) {
+	for _, propertyName := range []string{"a,b", `a"b`, " a", "a[b", ""} {
+		document, err := json.Marshal(map[string]any{
+			"type":       "object",
+			"properties": map[string]any{propertyName: map[string]any{"type": "string"}},
+		})
+		if err != nil {
+			t.Fatalf("json marshal: %v", err)
+		}
+
+		_, err = jsonschema.Convert(document, "names")
+		if !errors.Is(err, typeGenerationErrors.ErrUnsupportedName) {
+			t.Errorf("%q: got %v, want %v", propertyName, err, typeGenerationErrors.ErrUnsupportedName)
+		}
+	}
+}
+
+func TestConvertNullEnum(t *testing.T) {
+	document := `{"type": "object", "properties": {"value": {"enum": ["a", null]}}}`
+
+	_, err := jsonschema.Convert([]byte(document), "enum")
+	if !errors.Is(err, typeGenerationErrors.ErrUnsupportedValue) {
+		t.Errorf("got %v, want %v", err, typeGenerationErrors.ErrUnsupportedValue)
+	}
+}
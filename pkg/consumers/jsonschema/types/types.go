@@ -0,0 +1,810 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/internal/go_source"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/schema"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+)
+
+// rootTypeName is the name of the type of a root schema without a title.
+const rootTypeName = "Root"
+
+// registerUnionsName is the name of the function that registers the unions of the declarations.
+const registerUnionsName = "RegisterUnions"
+
+// contextImportPath is the path of the package of the context with which the unions are registered.
+const contextImportPath = "github.com/vphpersson/type_generation/pkg/types/context"
+
+// Context generates Go declarations from JSON Schema documents, with `json` and `jsonschema` tags that make the JSON
+// Schema producer generate equivalent schemas. Unions of definitions with a discriminator are declared as interface
+// types, which a generated RegisterUnions function registers with the context of a producer.
+type Context struct {
+	// PackageName is the name of the package of the generated source.
+	PackageName string
+	// Options are the options with which the schemas were produced. Constraints that the options imply, e.g. a
+	// minimum length of 1 with NonEmptyStrings, are not rendered as tags, and constraints that the options would add
+	// are negated.
+	Options jsonschemaTypes.Options
+
+	// definitionNames maps the names of definitions to the names of their Go types.
+	definitionNames map[string]string
+	// definitions maps the names of definitions to their schemas.
+	definitions map[string]*schema.Schema
+	// root is the Go type of the document, to which the references "#" and "#/" refer.
+	root      *goType
+	usedNames map[string]struct{}
+	// declarations are the sources of the declarations, in order.
+	declarations []string
+	// markerMethods maps the names of types to the marker methods of the unions of which they are variants.
+	markerMethods map[string][]string
+	// unionRegistrations are the sources of the statements that register the unions with discriminators.
+	unionRegistrations []string
+	imports            map[string]struct{}
+}
+
+// referenceName returns the name of the definition to which the reference refers, i.e. the last segment of a
+// fragment such as "#/$defs/Name", or the file name without extension of a relative URI such as "Name.json".
+func referenceName(reference string) string {
+	if _, fragment, ok := strings.Cut(reference, "#"); ok && fragment != "" {
+		reference = fragment
+	} else {
+		reference, _, _ = strings.Cut(reference, "#")
+		reference = strings.TrimSuffix(reference, ".json")
+	}
+
+	return reference[strings.LastIndex(reference, "/")+1:]
+}
+
+// isRootReference reports whether the reference refers to the document itself rather than to a definition.
+func isRootReference(reference string) bool {
+	return reference == "#" || reference == "#/"
+}
+
+// isTagName reports whether the property name can be the name of both a `json` tag, whose name encoding/json ignores
+// if it contains characters other than letters, digits and some punctuation, and a `jsonschema` tag, which trims
+// spaces and splits at commas outside of brackets.
+func isTagName(propertyName string) bool {
+	if propertyName == "" {
+		return false
+	}
+	for _, r := range propertyName {
+		if !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	jsonschemaTag, err := tag.New(propertyName + ",optional")
+	return err == nil && jsonschemaTag != nil && jsonschemaTag.Name == propertyName
+}
+
+// referencedSchema returns the reference of a schema that refers to a definition, directly or, as for draft-07, as the
+// first schema of `allOf`.
+func referencedSchema(s *schema.Schema) string {
+	if s.Ref != "" {
+		return s.Ref
+	}
+	if len(s.AllOf) == 1 && s.AllOf[0] != nil {
+		return s.AllOf[0].Ref
+	}
+	return ""
+}
+
+// singleType returns the type of a schema with exactly one type other than `null`, or an empty string.
+func singleType(s *schema.Schema) string {
+	types := slices.DeleteFunc(slices.Clone(s.Type), func(t string) bool { return t == "null" })
+	if len(types) != 1 {
+		return ""
+	}
+	return types[0]
+}
+
+// unwrapNullable returns the schema that a schema extends to also accept `null`, with a type list or with `anyOf`,
+// and whether it does so.
+func unwrapNullable(s *schema.Schema) (*schema.Schema, bool) {
+	if slices.Contains(s.Type, "null") {
+		return s, true
+	}
+
+	if len(s.AnyOf) == 2 {
+		for i, alternative := range s.AnyOf {
+			if alternative != nil && slices.Equal(alternative.Type, schema.Type{"null"}) && s.AnyOf[1-i] != nil {
+				return s.AnyOf[1-i], true
+			}
+		}
+	}
+
+	return s, false
+}
+
+func isStructSchema(s *schema.Schema) bool {
+	return s != nil && s.Properties != nil && (len(s.Type) == 0 || singleType(s) == "object")
+}
+
+// unionVariants returns the names of the definitions of the variants of a schema that is one of several definitions,
+// or nil if the schema is not such a union.
+func unionVariants(s *schema.Schema) []string {
+	if len(s.OneOf) == 0 {
+		return nil
+	}
+
+	var variantNames []string
+	for _, variant := range s.OneOf {
+		if variant == nil {
+			return nil
+		}
+		reference := referencedSchema(variant)
+		if reference == "" {
+			return nil
+		}
+		variantNames = append(variantNames, referenceName(reference))
+	}
+
+	return variantNames
+}
+
+// unionDiscriminator returns the name of the property whose constant string value identifies the variants of the
+// union, and the values in the order of the variants, or an empty string if there is none.
+func unionDiscriminator(s *schema.Schema) (string, []string) {
+	discriminator := ""
+	var tags []string
+	for _, variant := range s.OneOf {
+		keys := variant.Properties.Keys()
+		if len(keys) != 1 {
+			return "", nil
+		}
+		propertySchema, _ := variant.Properties.Get(keys[0])
+		if propertySchema == nil {
+			return "", nil
+		}
+		tag, ok := propertySchema.Const.(string)
+		if !ok {
+			return "", nil
+		}
+		if discriminator != "" && keys[0] != discriminator {
+			return "", nil
+		}
+		discriminator = keys[0]
+		tags = append(tags, tag)
+	}
+
+	return discriminator, tags
+}
+
+// formatValue formats a value of an enum, a const, a default or examples as a value of a tag.
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quoteValue(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedValue, value),
+			value,
+		)
+	}
+}
+
+// quoteValue quotes a value of a tag with single quotes if it contains characters that delimit options or lists.
+func quoteValue(value string) string {
+	if value != "" && value == strings.TrimSpace(value) && !strings.ContainsAny(value, ",[]'") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func formatList(values []any) (string, error) {
+	var formattedValues []string
+	for _, value := range values {
+		// The nullability of a value is represented by its type.
+		if value == nil {
+			continue
+		}
+
+		formattedValue, err := formatValue(value)
+		if err != nil {
+			return "", fmt.Errorf("format value: %w", err)
+		}
+		formattedValues = append(formattedValues, formattedValue)
+	}
+
+	return "[" + strings.Join(formattedValues, ",") + "]", nil
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// tagOptions returns the options of a `jsonschema` tag that describe the constraints and annotations of a property
+// schema, in the order of the tag's vocabulary.
+func (c *Context) tagOptions(s *schema.Schema, goType string) ([]string, error) {
+	var options []string
+
+	schemaType := singleType(s)
+
+	if s.Format != "" && !(s.Format == "date-time" && goType == "time.Time") {
+		options = append(options, "format:"+quoteValue(s.Format))
+	}
+
+	if schemaType == "string" {
+		switch {
+		case s.MinLength == nil && c.Options.NonEmptyStrings:
+			options = append(options, "minlength:0")
+		case s.MinLength != nil && !(*s.MinLength == 1 && c.Options.NonEmptyStrings):
+			options = append(options, "minlength:"+strconv.Itoa(*s.MinLength))
+		}
+	}
+	if s.MaxLength != nil {
+		options = append(options, "maxlength:"+strconv.Itoa(*s.MaxLength))
+	}
+	if s.Pattern != "" {
+		options = append(options, "pattern:"+quoteValue(s.Pattern))
+	}
+
+	for _, bound := range []struct {
+		key   string
+		value *float64
+	}{
+		{"minimum", s.Minimum},
+		{"maximum", s.Maximum},
+		{"exclusiveminimum", s.ExclusiveMinimum},
+		{"exclusivemaximum", s.ExclusiveMaximum},
+		{"multipleof", s.MultipleOf},
+	} {
+		if bound.value != nil {
+			options = append(options, bound.key+":"+formatFloat(*bound.value))
+		}
+	}
+
+	if schemaType == "array" {
+		switch {
+		case s.MinItems == nil && c.Options.NonEmptyArrays:
+			options = append(options, "minitems:0")
+		case s.MinItems != nil && !(*s.MinItems == 1 && c.Options.NonEmptyArrays):
+			options = append(options, "minitems:"+strconv.Itoa(*s.MinItems))
+		}
+	}
+	if s.MaxItems != nil {
+		options = append(options, "maxitems:"+strconv.Itoa(*s.MaxItems))
+	}
+	if s.UniqueItems {
+		options = append(options, "uniqueitems")
+	}
+	if s.MinProperties != nil {
+		options = append(options, "minproperties:"+strconv.Itoa(*s.MinProperties))
+	}
+	if s.MaxProperties != nil {
+		options = append(options, "maxproperties:"+strconv.Itoa(*s.MaxProperties))
+	}
+
+	if s.Enum != nil {
+		// The producer adds `null` to the enum of a nullable type, but not to that of `any`, whose schema accepts any
+		// value.
+		if goType == "any" && slices.Contains(s.Enum, nil) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: null in the enum of a value of any type", typeGenerationErrors.ErrUnsupportedValue),
+				s.Enum,
+			)
+		}
+
+		enum, err := formatList(s.Enum)
+		if err != nil {
+			return nil, fmt.Errorf("format list (enum): %w", err)
+		}
+		options = append(options, "enum:"+enum)
+	}
+	for _, value := range []struct {
+		key   string
+		value any
+	}{
+		{"const", s.Const},
+		{"default", s.Default},
+	} {
+		if value.value == nil {
+			continue
+		}
+		formattedValue, err := formatValue(value.value)
+		if err != nil {
+			return nil, fmt.Errorf("format value (%s): %w", value.key, err)
+		}
+		options = append(options, value.key+":"+formattedValue)
+	}
+	if s.Examples != nil {
+		examples, err := formatList(s.Examples)
+		if err != nil {
+			return nil, fmt.Errorf("format list (examples): %w", err)
+		}
+		options = append(options, "examples:"+examples)
+	}
+
+	if s.Title != "" {
+		options = append(options, "title:"+quoteValue(s.Title))
+	}
+	if s.Description != "" {
+		options = append(options, "description:"+quoteValue(s.Description))
+	}
+	if s.Deprecated {
+		options = append(options, "deprecated")
+	}
+	if s.ReadOnly {
+		options = append(options, "readonly")
+	}
+	if s.WriteOnly {
+		options = append(options, "writeonly")
+	}
+
+	return options, nil
+}
+
+// goType describes the Go type of a schema.
+type goType struct {
+	expression string
+	nullable   bool
+	// indirect is true if the type is a pointer, slice or map type, or `any`, whose zero value is encoded as `null`
+	// or omitted with `omitempty`.
+	indirect bool
+	// isStruct is true if the type is a struct type or time.Time, which `omitempty` does not omit.
+	isStruct bool
+}
+
+// resolveGoType returns the Go type of a schema. Object schemas with properties are declared as struct types, named
+// by the provided name.
+func (c *Context) resolveGoType(s *schema.Schema, name string) (*goType, error) {
+	s, nullable := unwrapNullable(s)
+
+	if reference := referencedSchema(s); reference != "" {
+		if isRootReference(reference) {
+			if c.root == nil {
+				return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w (root)", motmedelErrors.ErrNotInMap), reference)
+			}
+			rootType := *c.root
+			rootType.nullable = nullable
+			return &rootType, nil
+		}
+
+		definitionName := referenceName(reference)
+		typeName, ok := c.definitionNames[definitionName]
+		if !ok {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w (definition)", motmedelErrors.ErrNotInMap),
+				reference,
+			)
+		}
+
+		definition := c.definitions[definitionName]
+		return &goType{
+			expression: typeName,
+			nullable:   nullable,
+			isStruct:   isStructSchema(definition),
+			// The Go type of a union is an interface type.
+			indirect: unionVariants(definition) != nil,
+		}, nil
+	}
+
+	switch singleType(s) {
+	case "string":
+		switch {
+		case s.Format == "date-time":
+			c.imports["time"] = struct{}{}
+			return &goType{expression: "time.Time", nullable: nullable, isStruct: true}, nil
+		case s.ContentEncoding == "base64":
+			return &goType{expression: "[]byte", nullable: nullable, indirect: true}, nil
+		default:
+			return &goType{expression: "string", nullable: nullable}, nil
+		}
+	case "integer":
+		return &goType{expression: "int", nullable: nullable}, nil
+	case "number":
+		return &goType{expression: "float64", nullable: nullable}, nil
+	case "boolean":
+		return &goType{expression: "bool", nullable: nullable}, nil
+	case "array":
+		// Go slices, and thus the tags, cannot describe items by position.
+		if len(s.PrefixItems) > 0 {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: prefixItems or an items array", typeGenerationErrors.ErrUnsupportedKeyword),
+				name,
+			)
+		}
+
+		elemExpression := "any"
+		if s.Items != nil {
+			elemType, err := c.resolveGoType(s.Items, name+"Item")
+			if err != nil {
+				return nil, fmt.Errorf("resolve go type (items): %w", err)
+			}
+			elemExpression = elemType.expression
+			if elemType.nullable && !elemType.indirect {
+				elemExpression = "*" + elemExpression
+			}
+		}
+		return &goType{expression: "[]" + elemExpression, nullable: nullable, indirect: true}, nil
+	}
+
+	if isStructSchema(s) {
+		typeName := go_source.MakeUniqueName(name, c.usedNames)
+		if err := c.declareStruct(typeName, s); err != nil {
+			return nil, fmt.Errorf("declare struct: %w", err)
+		}
+		return &goType{expression: typeName, nullable: nullable, isStruct: true}, nil
+	}
+
+	if singleType(s) == "object" {
+		valueExpression := "any"
+		if valueSchema := s.AdditionalProperties; valueSchema != nil && valueSchema.Bool == nil {
+			valueType, err := c.resolveGoType(valueSchema, name+"Value")
+			if err != nil {
+				return nil, fmt.Errorf("resolve go type (additional properties): %w", err)
+			}
+			valueExpression = valueType.expression
+			if valueType.nullable && !valueType.indirect {
+				valueExpression = "*" + valueExpression
+			}
+		}
+		return &goType{expression: "map[string]" + valueExpression, nullable: nullable, indirect: true}, nil
+	}
+
+	// The schema accepts values of several types, or any value.
+	return &goType{expression: "any", nullable: nullable, indirect: true}, nil
+}
+
+// renderField renders the field of a property of a struct type with the provided name.
+func (c *Context) renderField(
+	structName string,
+	propertyName string,
+	propertySchema *schema.Schema,
+	required bool,
+	usedFieldNames map[string]struct{},
+) (string, error) {
+	if !isTagName(propertyName) {
+		return "", motmedelErrors.NewWithTrace(
+			fmt.Errorf(
+				"%w: the property name %q cannot be expressed in tags",
+				typeGenerationErrors.ErrUnsupportedName,
+				propertyName,
+			),
+			structName,
+		)
+	}
+
+	fieldName := go_source.ExportedIdentifier(propertyName)
+	if fieldName == "" {
+		fieldName = "Field"
+	}
+	fieldName = go_source.MakeUniqueName(fieldName, usedFieldNames)
+
+	fieldType, err := c.resolveGoType(propertySchema, structName+fieldName)
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("resolve go type: %w", err), propertyName)
+	}
+
+	// json.Marshal() omits empty values with `omitempty`, in which case they are neither required nor null; values
+	// whose zero value is null are otherwise nullable. Struct values are not omitted, and are thus pointers.
+	omitEmpty := !required && !fieldType.nullable
+	expression := fieldType.expression
+	if !fieldType.indirect && (fieldType.nullable || (omitEmpty && fieldType.isStruct)) {
+		expression = "*" + expression
+	}
+
+	jsonTagValue := propertyName
+	if omitEmpty {
+		jsonTagValue += ",omitempty"
+	} else if propertyName == "-" {
+		// The `json` tag "-" skips the field, unlike "-,".
+		jsonTagValue += ","
+	}
+	pairs := [][2]string{{"json", jsonTagValue}}
+
+	constrainedSchema, _ := unwrapNullable(propertySchema)
+	tagOptions, err := c.tagOptions(constrainedSchema, fieldType.expression)
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("tag options: %w", err), propertyName)
+	}
+	// Optional properties that are nullable are only expressible with the `optional` option. With a `jsonschema` tag,
+	// the producer does not consider `omitempty`, thus the option is then needed for all optional properties.
+	if !required && (fieldType.nullable || len(tagOptions) > 0) {
+		tagOptions = append([]string{"optional"}, tagOptions...)
+	}
+	if len(tagOptions) > 0 {
+		pairs = append(pairs, [2]string{"jsonschema", strings.Join(append([]string{propertyName}, tagOptions...), ",")})
+	}
+
+	return fmt.Sprintf("\t%s %s %s\n", fieldName, expression, go_source.StructTag(pairs...)), nil
+}
+
+// additionalPropertiesField renders a `_` field that overrides whether the object allows additional properties, if the
+// schema differs from what the options imply.
+func (c *Context) additionalPropertiesField(s *schema.Schema) string {
+	allowsAdditionalProperties := s.AdditionalProperties == nil ||
+		(s.AdditionalProperties.Bool != nil && *s.AdditionalProperties.Bool)
+	if allowsAdditionalProperties != c.Options.DisallowAdditionalProperties {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"\t_ struct{} %s\n",
+		go_source.StructTag(
+			[2]string{"jsonschema", ",additionalproperties:" + strconv.FormatBool(allowsAdditionalProperties)},
+		),
+	)
+}
+
+// comment renders the description of a schema as a comment.
+func comment(s *schema.Schema) string {
+	if s.Description == "" {
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, line := range strings.Split(s.Description, "\n") {
+		builder.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+
+	return builder.String()
+}
+
+// declareStruct declares a struct type with a field per property of the object schema, in order. The struct types of
+// object schemas of properties are declared after it.
+func (c *Context) declareStruct(name string, s *schema.Schema) error {
+	index := len(c.declarations)
+	c.declarations = append(c.declarations, "")
+
+	var builder strings.Builder
+	builder.WriteString(comment(s))
+	builder.WriteString("type " + name + " struct {\n")
+	builder.WriteString(c.additionalPropertiesField(s))
+
+	usedFieldNames := map[string]struct{}{}
+	for _, propertyName := range s.Properties.Keys() {
+		propertySchema, _ := s.Properties.Get(propertyName)
+		if propertySchema == nil {
+			continue
+		}
+
+		field, err := c.renderField(
+			name,
+			propertyName,
+			propertySchema,
+			slices.Contains(s.Required, propertyName),
+			usedFieldNames,
+		)
+		if err != nil {
+			return motmedelErrors.New(fmt.Errorf("render field: %w", err), name)
+		}
+		builder.WriteString(field)
+	}
+
+	builder.WriteString("}")
+
+	c.declarations[index] = builder.String()
+
+	return nil
+}
+
+// declareUnion declares an interface type with a marker method that the types of the variants implement.
+func (c *Context) declareUnion(name string, s *schema.Schema, variantNames []string) error {
+	var variantTypeNames []string
+	for _, variantName := range variantNames {
+		variantTypeName, ok := c.definitionNames[variantName]
+		if !ok {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("%w (variant)", motmedelErrors.ErrNotInMap), variantName)
+		}
+		variantTypeNames = append(variantTypeNames, variantTypeName)
+	}
+
+	markerMethod := "is" + name
+	for _, variantTypeName := range variantTypeNames {
+		c.markerMethods[variantTypeName] = append(c.markerMethods[variantTypeName], markerMethod)
+	}
+
+	discriminator, tags := unionDiscriminator(s)
+
+	var builder strings.Builder
+	builder.WriteString(comment(s))
+	builder.WriteString(fmt.Sprintf("// %s is one of %s", name, strings.Join(variantTypeNames, ", ")))
+	if discriminator != "" {
+		builder.WriteString(fmt.Sprintf(", discriminated by the %q property", discriminator))
+	}
+	builder.WriteString(".\n")
+	if discriminator != "" {
+		c.unionRegistrations = append(
+			c.unionRegistrations,
+			unionRegistration(name, discriminator, tags, variantTypeNames),
+		)
+	} else {
+		builder.WriteString("// It cannot be registered as a union, as it has no discriminator.\n")
+	}
+	builder.WriteString(fmt.Sprintf("type %s interface {\n\t%s()\n}", name, markerMethod))
+
+	c.declarations = append(c.declarations, builder.String())
+
+	return nil
+}
+
+// unionRegistration renders the statement that registers the union with the discriminator, which maps the tags to the
+// types of the variants.
+func unionRegistration(name string, discriminator string, tags []string, variantTypeNames []string) string {
+	var builder strings.Builder
+	builder.WriteString("if _, err := c.RegisterUnion(\n")
+	builder.WriteString(fmt.Sprintf("reflect.TypeFor[%s](),\n", name))
+	builder.WriteString(strconv.Quote(discriminator) + ",\n")
+	builder.WriteString("map[string]reflect.Type{\n")
+	for i, tag := range tags {
+		builder.WriteString(fmt.Sprintf("%s: reflect.TypeFor[%s](),\n", strconv.Quote(tag), variantTypeNames[i]))
+	}
+	builder.WriteString("},\n); err != nil {\n")
+	builder.WriteString(fmt.Sprintf("return fmt.Errorf(\"register union (%s): %%w\", err)\n}", name))
+
+	return builder.String()
+}
+
+// registerUnionsDeclaration renders a function that registers the unions with a context, as the producers represent
+// fields of interface types as any value otherwise.
+func (c *Context) registerUnionsDeclaration() string {
+	c.imports["fmt"] = struct{}{}
+	c.imports["reflect"] = struct{}{}
+	c.imports[contextImportPath] = struct{}{}
+
+	functionName := go_source.MakeUniqueName(registerUnionsName, c.usedNames)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf(
+		"// %s registers the unions with the context, so that its producers represent them as unions of their\n"+
+			"// variants.\n",
+		functionName,
+	))
+	builder.WriteString("func " + functionName + "(c *context.Context) error {\n")
+	for _, registration := range c.unionRegistrations {
+		builder.WriteString(registration + "\n\n")
+	}
+	builder.WriteString("return nil\n}")
+
+	return builder.String()
+}
+
+// declare declares the type of a definition or a root schema.
+func (c *Context) declare(name string, s *schema.Schema) error {
+	if variantNames := unionVariants(s); variantNames != nil {
+		return c.declareUnion(name, s, variantNames)
+	}
+
+	if isStructSchema(s) {
+		return c.declareStruct(name, s)
+	}
+
+	underlyingType, err := c.resolveGoType(s, name)
+	if err != nil {
+		return fmt.Errorf("resolve go type: %w", err)
+	}
+
+	c.declarations = append(c.declarations, comment(s)+"type "+name+" "+underlyingType.expression)
+
+	return nil
+}
+
+// isContainer reports whether a document only contains definitions, and possibly refers to one of them, rather than
+// describing a value of its own.
+func isContainer(document *schema.Schema) bool {
+	remainder := *document
+	remainder.Schema = ""
+	remainder.ID = ""
+	remainder.Comment = ""
+	remainder.Title = ""
+	remainder.Description = ""
+	remainder.Defs = nil
+	remainder.Definitions = nil
+	remainder.Extensions = nil
+
+	if reference := referencedSchema(&remainder); reference != "" {
+		remainder.Ref = ""
+		remainder.AllOf = nil
+	}
+
+	return remainder.IsEmpty()
+}
+
+// Render renders Go declarations for the definitions of the document, in order, and for the document itself if it
+// describes a value other than one of the definitions, named by its title.
+func (c *Context) Render(document *schema.Schema) (string, error) {
+	if document == nil {
+		return "", nil
+	}
+
+	c.definitionNames = map[string]string{}
+	c.definitions = map[string]*schema.Schema{}
+	c.root = nil
+	c.usedNames = map[string]struct{}{}
+	c.declarations = nil
+	c.markerMethods = map[string][]string{}
+	c.unionRegistrations = nil
+	c.imports = map[string]struct{}{}
+
+	definitions := document.Defs
+	if definitions == nil {
+		definitions = document.Definitions
+	}
+
+	// Name all definitions before declaring them, as they may refer to each other.
+	for _, definitionName := range definitions.Keys() {
+		typeName := go_source.ExportedIdentifier(definitionName)
+		if typeName == "" {
+			typeName = "Definition"
+		}
+		c.definitionNames[definitionName] = go_source.MakeUniqueName(typeName, c.usedNames)
+		c.definitions[definitionName], _ = definitions.Get(definitionName)
+	}
+
+	if !isContainer(document) {
+		rootName := go_source.ExportedIdentifier(document.Title)
+		if rootName == "" {
+			rootName = rootTypeName
+		}
+		rootName = go_source.MakeUniqueName(rootName, c.usedNames)
+
+		rootSchema := *document
+		rootSchema.Defs = nil
+		rootSchema.Definitions = nil
+		c.root = &goType{
+			expression: rootName,
+			isStruct:   isStructSchema(&rootSchema),
+			indirect:   unionVariants(&rootSchema) != nil,
+		}
+		if err := c.declare(rootName, &rootSchema); err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("declare (root): %w", err), rootName)
+		}
+	} else if reference := referencedSchema(document); reference != "" && !isRootReference(reference) {
+		// The document is the definition to which it refers.
+		rootType, err := c.resolveGoType(document, rootTypeName)
+		if err != nil {
+			return "", fmt.Errorf("resolve go type (root): %w", err)
+		}
+		c.root = rootType
+	} else {
+		// The document accepts any value.
+		c.root = &goType{expression: "any", indirect: true}
+	}
+
+	for _, definitionName := range definitions.Keys() {
+		definitionSchema := c.definitions[definitionName]
+		if definitionSchema == nil {
+			continue
+		}
+
+		typeName := c.definitionNames[definitionName]
+		if err := c.declare(typeName, definitionSchema); err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("declare: %w", err), definitionName)
+		}
+	}
+
+	// The marker methods of the variants of unions follow the declarations.
+	declarations := slices.Clone(c.declarations)
+	for _, definitionName := range definitions.Keys() {
+		typeName := c.definitionNames[definitionName]
+		for _, markerMethod := range c.markerMethods[typeName] {
+			declarations = append(declarations, fmt.Sprintf("func (%s) %s() {}", typeName, markerMethod))
+		}
+	}
+	if len(c.unionRegistrations) > 0 {
+		declarations = append(declarations, c.registerUnionsDeclaration())
+	}
+
+	source, err := go_source.Render(c.PackageName, c.imports, declarations)
+	if err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+
+	return source, nil
+}
@@ -0,0 +1,34 @@
+package round_trip
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Group struct {
+	Name string `postgres:"name,unique"`
+}
+
+type User struct {
+	Email   string          `postgres:"email,unique,indexed"`
+	Nick    *string         `postgres:"nick,nullable,default:'anon, the ''great'''"`
+	Age     int16           `postgres:"age,check:(age >= 0)"`
+	Score   float64         `postgres:"score,default:0"`
+	Created time.Time       `postgres:"created,default:now()"`
+	Data    json.RawMessage `postgres:"data,nullable"`
+	Raw     []byte          `postgres:"Raw"`
+	Tags    []string        `postgres:"Tags"`
+	GroupId *Group          `postgres:"group_id,nullable,ondelete:SET NULL,onupdate:CASCADE"`
+	Org     string          `postgres:"org,uniquecomposite"`
+	Handle  string          `postgres:"handle,uniquecomposite"`
+	Slug    string          `postgres:"slug,generatedstored:lower(handle)"`
+	Price   string          `postgres:"price,type:numeric(10,2)"`
+	Ok      bool            `postgres:"Ok"`
+	TagId   int64           `postgres:"tag_id,type:bigint REFERENCES tag(id)"`
+	Groups  []*Group
+}
+
+type Tag struct {
+	Id    int64  `postgres:"id,primarykey,type:bigint GENERATED ALWAYS AS IDENTITY"`
+	Label string `postgres:"label,check:length(label) > 0"`
+}
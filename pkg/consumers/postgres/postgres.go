@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"fmt"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/consumers/postgres/types"
+)
+
+// Convert generates Go declarations, in a package with the provided name, from Postgres DDL. The tags of the
+// declarations make postgres.Convert generate equivalent tables.
+func Convert(ddl string, packageName string) (string, error) {
+	consumerContext := types.Context{PackageName: packageName}
+	if err := consumerContext.Add(ddl); err != nil {
+		return "", fmt.Errorf("add: %w", err)
+	}
+
+	output, err := consumerContext.Render()
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("render: %w", err), consumerContext)
+	}
+
+	return output, nil
+}
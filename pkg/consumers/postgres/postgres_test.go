@@ -0,0 +1,110 @@
+package postgres_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vphpersson/type_generation/pkg/consumers/postgres"
+	"github.com/vphpersson/type_generation/pkg/consumers/postgres/internal/round_trip"
+	postgresProducer "github.com/vphpersson/type_generation/pkg/producers/postgres"
+)
+
+var update = flag.Bool("update", false, "update the generated round trip package")
+
+// roundTripPath is the path of the source generated from the DDL of the tables, whose declarations are converted back.
+var roundTripPath = filepath.Join("internal", "round_trip", "round_trip.go")
+
+type Group struct {
+	Name string `postgres:"name,unique"`
+}
+
+type Tag struct {
+	Id    int64  `postgres:"id,primarykey,type:bigint GENERATED ALWAYS AS IDENTITY"`
+	Label string `postgres:"label,check:length(label) > 0"`
+}
+
+type User struct {
+	Email   string          `postgres:"email,unique,indexed"`
+	Nick    *string         `postgres:"nick,nullable,default:'anon, the ''great'''"`
+	Age     int16           `postgres:"age,check:(age >= 0)"`
+	Score   float64         `postgres:"score,default:0"`
+	Created time.Time       `postgres:"created,default:now()"`
+	Data    json.RawMessage `postgres:"data,nullable"`
+	Raw     []byte
+	Tags    []string
+	Group   *Group `postgres:"group_id,nullable,ondelete:SET NULL,onupdate:CASCADE"`
+	Org     string `postgres:"org,uniquecomposite"`
+	Handle  string `postgres:"handle,uniquecomposite"`
+	Slug    string `postgres:"slug,generatedstored:lower(handle)"`
+	Price   string `postgres:"price,type:numeric(10,2)"`
+	Groups  []*Group
+	Ok      bool
+	TagRef  int64 `postgres:"tag_id,type:bigint REFERENCES tag(id)"`
+}
+
+// TestConvertRoundTrip converts the DDL of Go declarations to Go declarations, and checks that their DDL is the same.
+func TestConvertRoundTrip(t *testing.T) {
+	ddl, err := postgresProducer.Convert(User{}, Group{}, Tag{})
+	if err != nil {
+		t.Fatalf("convert (go): %v", err)
+	}
+
+	source, err := postgres.Convert(ddl, "round_trip")
+	if err != nil {
+		t.Fatalf("convert (ddl): %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(roundTripPath, []byte(source), 0o644); err != nil {
+			t.Fatalf("os write file: %v", err)
+		}
+	}
+
+	expectedSource, err := os.ReadFile(roundTripPath)
+	if err != nil {
+		t.Fatalf("os read file: %v", err)
+	}
+	if source != string(expectedSource) {
+		t.Fatalf("the source differs from %s; run the test with -update:\n%s", roundTripPath, source)
+	}
+
+	roundTripDDL, err := postgresProducer.Convert(round_trip.User{}, round_trip.Group{}, round_trip.Tag{})
+	if err != nil {
+		t.Fatalf("convert (round trip): %v", err)
+	}
+	if roundTripDDL != ddl {
+		t.Errorf("the DDL differs after the round trip:\n%s\n%s", ddl, roundTripDDL)
+	}
+}
+
+// TestConvertPartialIndex checks that indices that tags cannot express, such as partial indices, are not rendered as
+// tags of their columns.
+func TestConvertPartialIndex(t *testing.T) {
+	ddl := `
+		CREATE TABLE users (id bigint PRIMARY KEY, email text NOT NULL);
+		CREATE UNIQUE INDEX users_email ON users (email) WHERE id > 0;
+		CREATE INDEX ON users USING hash (email);
+	`
+
+	source, err := postgres.Convert(ddl, "users")
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+
+	for _, statement := range []string{
+		"CREATE UNIQUE INDEX ON users (email) WHERE id > 0",
+		"CREATE INDEX ON users USING hash (email)",
+	} {
+		if !strings.Contains(source, statement) {
+			t.Errorf("the source does not mention %q:\n%s", statement, source)
+		}
+	}
+	if strings.Contains(source, "unique") || strings.Contains(source, "indexed") {
+		t.Errorf("the source has index tags:\n%s", source)
+	}
+}
@@ -0,0 +1,734 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+)
+
+var (
+	ErrSyntax                = errors.New("syntax error")
+	ErrUnterminated          = errors.New("unterminated literal or comment")
+	ErrUnexpectedEnd         = errors.New("unexpected end of statement")
+	ErrUnsupportedConstraint = errors.New("unsupported constraint")
+)
+
+type tokenKind int
+
+const (
+	tokenKindWord tokenKind = iota
+	tokenKindQuotedIdentifier
+	tokenKindString
+	tokenKindNumber
+	tokenKindSymbol
+)
+
+// token is a lexical token of a statement. The text of a quoted identifier is the identifier without quotes; the
+// offsets delimit the token in the source.
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+// isKeyword reports whether the token is the unquoted keyword, case-insensitively.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenKindWord && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.kind == tokenKindSymbol && t.text == symbol
+}
+
+func isWordRune(r rune, first bool) bool {
+	return r == '_' || unicode.IsLetter(r) || (!first && (unicode.IsDigit(r) || r == '$'))
+}
+
+// tokenize splits SQL source into tokens, skipping whitespace and comments.
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	// offsets maps rune indices to byte offsets.
+	offsets := make([]int, len(runes)+1)
+	offset := 0
+	for i, r := range runes {
+		offsets[i] = offset
+		offset += len(string(r))
+	}
+	offsets[len(runes)] = offset
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := strings.Index(string(runes[i+2:]), "*/")
+			if end == -1 {
+				return nil, motmedelErrors.NewWithTrace(ErrUnterminated, "comment")
+			}
+			i += 2 + len([]rune(string(runes[i+2:])[:end])) + 2
+			continue
+		case r == '\'' || r == '"':
+			var builder strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, motmedelErrors.NewWithTrace(ErrUnterminated, string(runes[start:]))
+				}
+				if runes[i] == r {
+					// A doubled quote escapes the quote.
+					if i+1 < len(runes) && runes[i+1] == r {
+						builder.WriteRune(r)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				builder.WriteRune(runes[i])
+				i++
+			}
+
+			kind := tokenKindString
+			if r == '"' {
+				kind = tokenKindQuotedIdentifier
+			}
+			tokens = append(tokens, token{kind: kind, text: builder.String(), start: offsets[start], end: offsets[i]})
+			continue
+		case r == '$':
+			// A dollar-quoted string, e.g. $$text$$ or $tag$text$tag$.
+			j := i + 1
+			for j < len(runes) && isWordRune(runes[j], j == i+1) && runes[j] != '$' {
+				j++
+			}
+			if j < len(runes) && runes[j] == '$' {
+				delimiter := string(runes[i : j+1])
+				rest := string(runes[j+1:])
+				end := strings.Index(rest, delimiter)
+				if end == -1 {
+					return nil, motmedelErrors.NewWithTrace(ErrUnterminated, string(runes[start:]))
+				}
+				i = j + 1 + len([]rune(rest[:end])) + len([]rune(delimiter))
+				tokens = append(
+					tokens,
+					token{kind: tokenKindString, text: rest[:end], start: offsets[start], end: offsets[i]},
+				)
+				continue
+			}
+		case isWordRune(r, true):
+			for i < len(runes) && isWordRune(runes[i], false) {
+				i++
+			}
+			tokens = append(
+				tokens,
+				token{kind: tokenKindWord, text: string(runes[start:i]), start: offsets[start], end: offsets[i]},
+			)
+			continue
+		case unicode.IsDigit(r):
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(
+				tokens,
+				token{kind: tokenKindNumber, text: string(runes[start:i]), start: offsets[start], end: offsets[i]},
+			)
+			continue
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			i += 2
+			tokens = append(tokens, token{kind: tokenKindSymbol, text: "::", start: offsets[start], end: offsets[i]})
+			continue
+		}
+
+		i++
+		tokens = append(tokens, token{kind: tokenKindSymbol, text: string(r), start: offsets[start], end: offsets[i]})
+	}
+
+	return tokens, nil
+}
+
+// splitStatements splits tokens into statements at semicolons, omitting empty statements.
+func splitStatements(tokens []token) [][]token {
+	var statements [][]token
+	start := 0
+	for i, t := range tokens {
+		if t.isSymbol(";") {
+			if i > start {
+				statements = append(statements, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		statements = append(statements, tokens[start:])
+	}
+
+	return statements
+}
+
+// splitTopLevel splits tokens at commas outside of parentheses and brackets.
+func splitTopLevel(tokens []token) [][]token {
+	var parts [][]token
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("(") || t.isSymbol("["):
+			depth++
+		case t.isSymbol(")") || t.isSymbol("]"):
+			depth--
+		case t.isSymbol(",") && depth == 0:
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, tokens[start:])
+}
+
+// parser parses a statement, with the source from which its tokens are, for recovering the text of expressions.
+type parser struct {
+	source string
+	tokens []token
+	index  int
+}
+
+func (p *parser) done() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.done() {
+		return token{}, false
+	}
+	return p.tokens[p.index], true
+}
+
+// acceptKeywords consumes the sequence of keywords if the next tokens are the sequence.
+func (p *parser) acceptKeywords(keywords ...string) bool {
+	if p.index+len(keywords) > len(p.tokens) {
+		return false
+	}
+	for i, keyword := range keywords {
+		if !p.tokens[p.index+i].isKeyword(keyword) {
+			return false
+		}
+	}
+
+	p.index += len(keywords)
+	return true
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if t, ok := p.peek(); ok && t.isSymbol(symbol) {
+		p.index++
+		return true
+	}
+	return false
+}
+
+// identifier consumes an identifier, returning it without quotes.
+func (p *parser) identifier() (string, error) {
+	t, ok := p.peek()
+	if !ok {
+		return "", motmedelErrors.NewWithTrace(ErrUnexpectedEnd)
+	}
+	if t.kind != tokenKindWord && t.kind != tokenKindQuotedIdentifier {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("%w: not an identifier", ErrSyntax), t.text)
+	}
+
+	p.index++
+	return t.text, nil
+}
+
+// qualifiedName consumes a possibly schema-qualified name, e.g. `public.users`, returning its last part.
+func (p *parser) qualifiedName() (string, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return "", fmt.Errorf("identifier: %w", err)
+	}
+
+	for p.acceptSymbol(".") {
+		name, err = p.identifier()
+		if err != nil {
+			return "", fmt.Errorf("identifier: %w", err)
+		}
+	}
+
+	return name, nil
+}
+
+// parenthesized consumes a parenthesized group, returning the tokens within it.
+func (p *parser) parenthesized() ([]token, error) {
+	if !p.acceptSymbol("(") {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: expected (", ErrSyntax))
+	}
+
+	start := p.index
+	depth := 1
+	for ; !p.done(); p.index++ {
+		switch t := p.tokens[p.index]; {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+			if depth == 0 {
+				inner := p.tokens[start:p.index]
+				p.index++
+				return inner, nil
+			}
+		}
+	}
+
+	return nil, motmedelErrors.NewWithTrace(ErrUnexpectedEnd)
+}
+
+// text returns the source text that the tokens span.
+func (p *parser) text(tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return p.source[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+// identifierList parses tokens of a comma-separated list of identifiers, e.g. the columns of a constraint. Elements
+// that are not plain identifiers, such as expressions, yield an error.
+func identifierList(tokens []token) ([]string, error) {
+	var identifiers []string
+	for _, element := range splitTopLevel(tokens) {
+		if len(element) != 1 || (element[0].kind != tokenKindWord && element[0].kind != tokenKindQuotedIdentifier) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: not an identifier", ErrSyntax),
+				element,
+			)
+		}
+		identifiers = append(identifiers, element[0].text)
+	}
+
+	return identifiers, nil
+}
+
+// columnConstraintKeywords are the keywords that start column constraints, and thus end types and expressions.
+var columnConstraintKeywords = []string{
+	"constraint", "not", "null", "primary", "unique", "default", "references", "check", "generated", "collate",
+}
+
+func isColumnConstraintKeyword(t token) bool {
+	return t.kind == tokenKindWord && slices.Contains(columnConstraintKeywords, strings.ToLower(t.text))
+}
+
+// until consumes tokens until a token, outside of parentheses, that satisfies the stop function, returning the
+// consumed tokens. At least one token is consumed.
+func (p *parser) until(stop func(token) bool) []token {
+	start := p.index
+	depth := 0
+	for ; !p.done(); p.index++ {
+		t := p.tokens[p.index]
+		switch {
+		case t.isSymbol("(") || t.isSymbol("["):
+			depth++
+		case t.isSymbol(")") || t.isSymbol("]"):
+			depth--
+		case depth == 0 && p.index > start && stop(t):
+			return p.tokens[start:p.index]
+		}
+	}
+
+	return p.tokens[start:]
+}
+
+// referentialActions are the keywords of the referential actions of foreign keys.
+var referentialActions = [][]string{{"cascade"}, {"restrict"}, {"no", "action"}, {"set", "null"}, {"set", "default"}}
+
+// referentialAction consumes a referential action, e.g. `SET NULL`.
+func (p *parser) referentialAction() (string, error) {
+	for _, action := range referentialActions {
+		start := p.index
+		if p.acceptKeywords(action...) {
+			return p.text(p.tokens[start:p.index]), nil
+		}
+	}
+
+	t, _ := p.peek()
+	return "", motmedelErrors.NewWithTrace(
+		fmt.Errorf("%w: not a referential action", ErrSyntax),
+		t.text,
+	)
+}
+
+// reference consumes the part of a foreign key constraint following `REFERENCES`.
+func (p *parser) reference() (*Reference, error) {
+	table, err := p.qualifiedName()
+	if err != nil {
+		return nil, fmt.Errorf("qualified name (table): %w", err)
+	}
+
+	reference := &Reference{Table: table}
+
+	if t, ok := p.peek(); ok && t.isSymbol("(") {
+		columnTokens, err := p.parenthesized()
+		if err != nil {
+			return nil, fmt.Errorf("parenthesized (columns): %w", err)
+		}
+		columns, err := identifierList(columnTokens)
+		if err != nil {
+			return nil, fmt.Errorf("identifier list (columns): %w", err)
+		}
+		if len(columns) != 1 {
+			return nil, motmedelErrors.NewWithTrace(ErrUnsupportedConstraint, p.text(columnTokens))
+		}
+		reference.Column = columns[0]
+	}
+
+	for {
+		switch {
+		case p.acceptKeywords("on", "delete"):
+			reference.OnDelete, err = p.referentialAction()
+		case p.acceptKeywords("on", "update"):
+			reference.OnUpdate, err = p.referentialAction()
+		case p.acceptKeywords("match", "full"), p.acceptKeywords("match", "partial"),
+			p.acceptKeywords("match", "simple"):
+		default:
+			return reference, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("referential action: %w", err)
+		}
+	}
+}
+
+// column parses a column definition.
+func (p *parser) column() (*Column, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return nil, fmt.Errorf("identifier (name): %w", err)
+	}
+
+	typeTokens := p.until(isColumnConstraintKeyword)
+	if len(typeTokens) == 0 {
+		return nil, motmedelErrors.NewWithTrace(ErrUnexpectedEnd, name)
+	}
+	column := &Column{Name: name, Type: strings.Join(strings.Fields(p.text(typeTokens)), " ")}
+
+	for !p.done() {
+		switch {
+		case p.acceptKeywords("constraint"):
+			if _, err := p.identifier(); err != nil {
+				return nil, fmt.Errorf("identifier (constraint name): %w", err)
+			}
+		case p.acceptKeywords("not", "null"):
+			column.NotNull = true
+		case p.acceptKeywords("null"):
+		case p.acceptKeywords("primary", "key"):
+			column.PrimaryKey = true
+		case p.acceptKeywords("unique"):
+			column.Unique = true
+		case p.acceptKeywords("default"):
+			column.Default = p.text(p.until(isColumnConstraintKeyword))
+		case p.acceptKeywords("check"):
+			checkTokens, err := p.parenthesized()
+			if err != nil {
+				return nil, fmt.Errorf("parenthesized (check): %w", err)
+			}
+			column.Check = p.text(checkTokens)
+		case p.acceptKeywords("references"):
+			column.References, err = p.reference()
+			if err != nil {
+				return nil, fmt.Errorf("reference: %w", err)
+			}
+		case p.acceptKeywords("generated", "always", "as", "identity"),
+			p.acceptKeywords("generated", "by", "default", "as", "identity"):
+			start := p.index - 4
+			if p.tokens[start].isKeyword("by") {
+				start--
+			}
+			if t, ok := p.peek(); ok && t.isSymbol("(") {
+				if _, err := p.parenthesized(); err != nil {
+					return nil, fmt.Errorf("parenthesized (identity options): %w", err)
+				}
+			}
+			column.Identity = p.text(p.tokens[start:p.index])
+		case p.acceptKeywords("generated", "always", "as"):
+			generatedTokens, err := p.parenthesized()
+			if err != nil {
+				return nil, fmt.Errorf("parenthesized (generated): %w", err)
+			}
+			column.Generated = p.text(generatedTokens)
+			column.GeneratedStored = p.acceptKeywords("stored")
+		case p.acceptKeywords("collate"):
+			start := p.index
+			if _, err := p.qualifiedName(); err != nil {
+				return nil, fmt.Errorf("qualified name (collation): %w", err)
+			}
+			column.Collation = p.text(p.tokens[start:p.index])
+		default:
+			t, _ := p.peek()
+			return nil, motmedelErrors.NewWithTrace(ErrUnsupportedConstraint, t.text)
+		}
+	}
+
+	return column, nil
+}
+
+// tableConstraint parses a table constraint, without the optional `CONSTRAINT name` prefix, and applies it to the
+// table. It reports whether the tokens are a table constraint.
+func (p *parser) tableConstraint(table *Table) (bool, error) {
+	if p.acceptKeywords("constraint") {
+		if _, err := p.identifier(); err != nil {
+			return false, fmt.Errorf("identifier (constraint name): %w", err)
+		}
+	}
+
+	switch {
+	case p.acceptKeywords("primary", "key"):
+		columnTokens, err := p.parenthesized()
+		if err != nil {
+			return false, fmt.Errorf("parenthesized (primary key): %w", err)
+		}
+		table.PrimaryKey, err = identifierList(columnTokens)
+		if err != nil {
+			return false, fmt.Errorf("identifier list (primary key): %w", err)
+		}
+	case p.acceptKeywords("unique"):
+		columnTokens, err := p.parenthesized()
+		if err != nil {
+			return false, fmt.Errorf("parenthesized (unique): %w", err)
+		}
+		columns, err := identifierList(columnTokens)
+		if err != nil {
+			return false, fmt.Errorf("identifier list (unique): %w", err)
+		}
+		table.UniqueConstraints = append(table.UniqueConstraints, columns)
+	case p.acceptKeywords("foreign", "key"):
+		columnTokens, err := p.parenthesized()
+		if err != nil {
+			return false, fmt.Errorf("parenthesized (foreign key): %w", err)
+		}
+		columns, err := identifierList(columnTokens)
+		if err != nil {
+			return false, fmt.Errorf("identifier list (foreign key): %w", err)
+		}
+		if len(columns) != 1 || !p.acceptKeywords("references") {
+			return false, motmedelErrors.NewWithTrace(ErrUnsupportedConstraint, p.text(p.tokens))
+		}
+		reference, err := p.reference()
+		if err != nil {
+			return false, fmt.Errorf("reference: %w", err)
+		}
+		column := table.column(columns[0])
+		if column == nil {
+			return false, motmedelErrors.NewWithTrace(fmt.Errorf("%w (column)", motmedelErrors.ErrNotInMap), columns[0])
+		}
+		column.References = reference
+	case p.acceptKeywords("check"):
+		checkTokens, err := p.parenthesized()
+		if err != nil {
+			return false, fmt.Errorf("parenthesized (check): %w", err)
+		}
+		table.Checks = append(table.Checks, p.text(checkTokens))
+	case p.acceptKeywords("exclude"):
+		return true, motmedelErrors.NewWithTrace(ErrUnsupportedConstraint, p.text(p.tokens))
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// createTable parses the remainder of a `CREATE TABLE` statement.
+func (p *parser) createTable() (*Table, error) {
+	p.acceptKeywords("if", "not", "exists")
+
+	name, err := p.qualifiedName()
+	if err != nil {
+		return nil, fmt.Errorf("qualified name (table): %w", err)
+	}
+
+	itemTokens, err := p.parenthesized()
+	if err != nil {
+		return nil, fmt.Errorf("parenthesized (table items): %w", err)
+	}
+
+	table := &Table{Name: name}
+
+	// Table constraints may refer to columns defined after them, thus columns are parsed first.
+	var constraintItems [][]token
+	for _, item := range splitTopLevel(itemTokens) {
+		if len(item) == 0 {
+			continue
+		}
+
+		if slices.ContainsFunc(
+			[]string{"constraint", "primary", "unique", "foreign", "check", "exclude"},
+			item[0].isKeyword,
+		) {
+			constraintItems = append(constraintItems, item)
+			continue
+		}
+		if item[0].isKeyword("like") {
+			return nil, motmedelErrors.NewWithTrace(ErrUnsupportedConstraint, p.text(item))
+		}
+
+		itemParser := &parser{source: p.source, tokens: item}
+		column, err := itemParser.column()
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("column: %w", err), p.text(item))
+		}
+		table.Columns = append(table.Columns, column)
+	}
+
+	for _, item := range constraintItems {
+		itemParser := &parser{source: p.source, tokens: item}
+		if _, err := itemParser.tableConstraint(table); err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("table constraint: %w", err), p.text(item))
+		}
+	}
+
+	return table, nil
+}
+
+// createIndex parses the remainder of a `CREATE [UNIQUE] INDEX` statement.
+func (p *parser) createIndex(unique bool) (*Index, error) {
+	p.acceptKeywords("concurrently")
+	p.acceptKeywords("if", "not", "exists")
+
+	if !p.acceptKeywords("on") {
+		if _, err := p.qualifiedName(); err != nil {
+			return nil, fmt.Errorf("qualified name (index): %w", err)
+		}
+		if !p.acceptKeywords("on") {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: expected ON", ErrSyntax))
+		}
+	}
+	p.acceptKeywords("only")
+
+	table, err := p.qualifiedName()
+	if err != nil {
+		return nil, fmt.Errorf("qualified name (table): %w", err)
+	}
+
+	index := &Index{Table: table, Unique: unique}
+
+	if p.acceptKeywords("using") {
+		index.Method, err = p.identifier()
+		if err != nil {
+			return nil, fmt.Errorf("identifier (method): %w", err)
+		}
+	}
+
+	columnTokens, err := p.parenthesized()
+	if err != nil {
+		return nil, fmt.Errorf("parenthesized (columns): %w", err)
+	}
+	// The clauses that follow, such as `INCLUDE`, `WITH` and the `WHERE` of partial indices, are kept as text.
+	index.Clauses = p.text(p.tokens[p.index:])
+
+	// Indices of expressions are kept as expressions.
+	if columns, err := identifierList(columnTokens); err == nil {
+		index.Columns = columns
+	} else {
+		index.Expression = p.text(columnTokens)
+	}
+
+	return index, nil
+}
+
+// alterTable parses the remainder of an `ALTER TABLE` statement, applying the added columns and constraints to the
+// table. Other actions are ignored.
+func (p *parser) alterTable(tables []*Table) error {
+	p.acceptKeywords("if", "exists")
+	p.acceptKeywords("only")
+
+	name, err := p.qualifiedName()
+	if err != nil {
+		return fmt.Errorf("qualified name (table): %w", err)
+	}
+
+	table := findTable(tables, name)
+	if table == nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("%w (table)", motmedelErrors.ErrNotInMap), name)
+	}
+
+	for _, action := range splitTopLevel(p.tokens[p.index:]) {
+		actionParser := &parser{source: p.source, tokens: action}
+		if !actionParser.acceptKeywords("add") {
+			continue
+		}
+
+		isTableConstraint, err := actionParser.tableConstraint(table)
+		if err != nil {
+			return motmedelErrors.New(fmt.Errorf("table constraint: %w", err), p.text(action))
+		}
+		if isTableConstraint {
+			continue
+		}
+
+		actionParser.acceptKeywords("column")
+		actionParser.acceptKeywords("if", "not", "exists")
+		column, err := actionParser.column()
+		if err != nil {
+			return motmedelErrors.New(fmt.Errorf("column: %w", err), p.text(action))
+		}
+		table.Columns = append(table.Columns, column)
+	}
+
+	return nil
+}
+
+// parse parses the `CREATE TABLE`, `CREATE INDEX` and `ALTER TABLE ... ADD` statements of SQL source, adding the
+// tables and indices to those previously parsed. Other statements are ignored.
+func parse(source string, tables []*Table, indices []*Index) ([]*Table, []*Index, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tokenize: %w", err)
+	}
+
+	for _, statement := range splitStatements(tokens) {
+		p := &parser{source: source, tokens: statement}
+
+		switch {
+		case p.acceptKeywords("create"):
+			if !p.acceptKeywords("global") {
+				p.acceptKeywords("local")
+			}
+			if !p.acceptKeywords("temporary") {
+				p.acceptKeywords("temp")
+			}
+			p.acceptKeywords("unlogged")
+
+			switch {
+			case p.acceptKeywords("table"):
+				table, err := p.createTable()
+				if err != nil {
+					return nil, nil, motmedelErrors.New(fmt.Errorf("create table: %w", err), p.text(statement))
+				}
+				tables = append(tables, table)
+			case p.acceptKeywords("unique", "index"), p.acceptKeywords("index"):
+				index, err := p.createIndex(statement[1].isKeyword("unique"))
+				if err != nil {
+					return nil, nil, motmedelErrors.New(fmt.Errorf("create index: %w", err), p.text(statement))
+				}
+				indices = append(indices, index)
+			}
+		case p.acceptKeywords("alter", "table"):
+			if err := p.alterTable(tables); err != nil {
+				return nil, nil, motmedelErrors.New(fmt.Errorf("alter table: %w", err), p.text(statement))
+			}
+		}
+	}
+
+	return tables, indices, nil
+}
@@ -0,0 +1,556 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/internal/go_source"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
+)
+
+// Reference is the foreign key reference of a column.
+type Reference struct {
+	Table string
+	// Column is the referenced column, or empty if the primary key of the table is referenced.
+	Column   string
+	OnDelete string
+	OnUpdate string
+}
+
+// Column is a column definition, with the constraints of the column definition and those of the table and its indices
+// that apply to only the column.
+type Column struct {
+	Name string
+	// Type is the declared type, with normalized whitespace.
+	Type       string
+	NotNull    bool
+	PrimaryKey bool
+	Unique     bool
+	// Default, Check and Generated are expressions as written.
+	Default         string
+	Check           string
+	Generated       string
+	GeneratedStored bool
+	// Collation is the collation as written, e.g. `"C"`.
+	Collation string
+	// Identity is the identity clause, e.g. `GENERATED ALWAYS AS IDENTITY`.
+	Identity   string
+	References *Reference
+}
+
+// Table is a table definition.
+type Table struct {
+	Name    string
+	Columns []*Column
+	// PrimaryKey is the columns of a primary key table constraint.
+	PrimaryKey []string
+	// UniqueConstraints are the columns of the unique table constraints.
+	UniqueConstraints [][]string
+	// Checks are the expressions of the check table constraints.
+	Checks []string
+}
+
+// column returns the column with the name, compared case-insensitively, or nil.
+func (t *Table) column(name string) *Column {
+	for _, column := range t.Columns {
+		if strings.EqualFold(column.Name, name) {
+			return column
+		}
+	}
+	return nil
+}
+
+// findTable returns the table with the name, compared case-insensitively, or nil.
+func findTable(tables []*Table, name string) *Table {
+	for _, table := range tables {
+		if strings.EqualFold(table.Name, name) {
+			return table
+		}
+	}
+	return nil
+}
+
+// Index is an index of a table, on either columns or an expression.
+type Index struct {
+	Table      string
+	Columns    []string
+	Expression string
+	Unique     bool
+	// Method is the index method of a `USING` clause, e.g. "gin", or empty for the default method.
+	Method string
+	// Clauses are the clauses that follow the indexed columns, e.g. the `WHERE` clause of a partial index.
+	Clauses string
+}
+
+// isPlain reports whether the index is a B-tree index of exactly one column, the only kind that tags express.
+func (index *Index) isPlain() bool {
+	return index.Expression == "" && len(index.Columns) == 1 && index.Clauses == "" &&
+		(index.Method == "" || strings.EqualFold(index.Method, "btree"))
+}
+
+// goType is the Go type of a column type, and the column type that the Postgres producer derives from it.
+type goType struct {
+	expression string
+	canonical  string
+	importPath string
+	// nilable reports whether nil values of the type are null, which is the case for slices.
+	nilable bool
+}
+
+var (
+	goTypeBool        = goType{expression: "bool", canonical: "boolean"}
+	goTypeString      = goType{expression: "string", canonical: "text"}
+	goTypeInt16       = goType{expression: "int16", canonical: "smallint"}
+	goTypeInt32       = goType{expression: "int32", canonical: "integer"}
+	goTypeInt64       = goType{expression: "int64", canonical: "bigint"}
+	goTypeFloat32     = goType{expression: "float32", canonical: "real"}
+	goTypeFloat64     = goType{expression: "float64", canonical: "double precision"}
+	goTypeTime        = goType{expression: "time.Time", canonical: "timestamptz", importPath: "time"}
+	goTypeBytes       = goType{expression: "[]byte", canonical: "bytea", nilable: true}
+	goTypeJSONMessage = goType{
+		expression: "json.RawMessage",
+		canonical:  "jsonb",
+		importPath: "encoding/json",
+		nilable:    true,
+	}
+)
+
+// goTypes maps lowercase column types to Go types. Types that are absent are strings.
+var goTypes = map[string]goType{
+	"boolean":                     goTypeBool,
+	"bool":                        goTypeBool,
+	"text":                        goTypeString,
+	"smallint":                    goTypeInt16,
+	"int2":                        goTypeInt16,
+	"smallserial":                 goTypeInt16,
+	"integer":                     goTypeInt32,
+	"int":                         goTypeInt32,
+	"int4":                        goTypeInt32,
+	"serial":                      goTypeInt32,
+	"bigint":                      goTypeInt64,
+	"int8":                        goTypeInt64,
+	"bigserial":                   goTypeInt64,
+	"real":                        goTypeFloat32,
+	"float4":                      goTypeFloat32,
+	"double precision":            goTypeFloat64,
+	"float8":                      goTypeFloat64,
+	"timestamptz":                 goTypeTime,
+	"timestamp with time zone":    goTypeTime,
+	"timestamp":                   goTypeTime,
+	"timestamp without time zone": goTypeTime,
+	"date":                        goTypeTime,
+	"bytea":                       goTypeBytes,
+	"jsonb":                       goTypeJSONMessage,
+	"json":                        goTypeJSONMessage,
+}
+
+// resolveGoType returns the Go type of a column type.
+func resolveGoType(columnType string) goType {
+	lowerColumnType := strings.ToLower(columnType)
+
+	if elementType, ok := strings.CutSuffix(lowerColumnType, "[]"); ok {
+		elementGoType := resolveGoType(strings.TrimSpace(elementType))
+		return goType{
+			expression: "[]" + elementGoType.expression,
+			canonical:  elementGoType.canonical + "[]",
+			importPath: elementGoType.importPath,
+			nilable:    true,
+		}
+	}
+
+	if t, ok := goTypes[lowerColumnType]; ok {
+		return t
+	}
+
+	return goTypeString
+}
+
+// isImplicitId reports whether the column is the `id` column that the Postgres producer adds to tables without a
+// primary key.
+func isImplicitId(table *Table, column *Column) bool {
+	return column.Name == "id" && strings.EqualFold(column.Type, "uuid") && column.PrimaryKey &&
+		len(table.PrimaryKey) == 0 && strings.ToLower(column.Default) == "gen_random_uuid()" && !column.Unique &&
+		column.Check == "" && column.Generated == "" && column.Collation == "" && column.Identity == "" &&
+		column.References == nil
+}
+
+// Context generates Go declarations from Postgres DDL, with `postgres` tags that make the Postgres producer generate
+// equivalent tables.
+type Context struct {
+	// PackageName is the name of the package of the generated source.
+	PackageName string
+	Tables      []*Table
+	Indices     []*Index
+}
+
+// Add parses the `CREATE TABLE`, `CREATE INDEX` and `ALTER TABLE ... ADD` statements of the DDL, adding the tables
+// and indices to those of the context. Other statements are ignored.
+func (c *Context) Add(ddl string) error {
+	tables, indices, err := parse(ddl, c.Tables, c.Indices)
+	if err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+
+	c.Tables = tables
+	c.Indices = indices
+
+	return nil
+}
+
+// renderer holds the state of a rendering of the tables of a context.
+type renderer struct {
+	c *Context
+	// structNames maps tables to the names of their Go types.
+	structNames map[*Table]string
+	// associations maps tables to the tables with which they are associated by associative tables.
+	associations map[*Table][]*Table
+	// associativeTables are the tables that are rendered as fields of slices rather than as types.
+	associativeTables map[*Table]struct{}
+	// indexedColumns and uniqueColumns are the columns of single-column indices.
+	indexedColumns map[*Column]struct{}
+	uniqueColumns  map[*Column]struct{}
+	// unexpressed maps tables to the constraints and indices that tags cannot express.
+	unexpressed map[*Table][]string
+	imports     map[string]struct{}
+}
+
+// structReference returns the table that a column refers to, if the column can be a pointer to the Go type of the
+// table, which is the case if the Postgres producer derives the reference from it: the reference is to the `id`
+// column of the table, of the same type as the column, and the table name is derived from the type name.
+func (r *renderer) structReference(column *Column) *Table {
+	reference := column.References
+	if reference == nil || (reference.Column != "" && !strings.EqualFold(reference.Column, "id")) {
+		return nil
+	}
+
+	table := findTable(r.c.Tables, reference.Table)
+	if table == nil {
+		return nil
+	}
+	if _, ok := r.associativeTables[table]; ok {
+		return nil
+	}
+	if naming_convention.SnakeCase.Apply(r.structNames[table]) != table.Name {
+		return nil
+	}
+
+	idColumn := table.column("id")
+	if idColumn == nil || idColumn.References != nil || idColumn.Identity != "" || idColumn.Collation != "" {
+		return nil
+	}
+	if !strings.EqualFold(idColumn.Type, column.Type) {
+		return nil
+	}
+
+	return table
+}
+
+// associate records the table as associative, i.e. as the table that the Postgres producer derives from a slice
+// field, if it is one.
+func (r *renderer) associate(table *Table) {
+	if len(table.Columns) != 2 || len(table.PrimaryKey) != 2 {
+		return
+	}
+
+	var associated []*Table
+	for i, column := range table.Columns {
+		if !strings.EqualFold(table.PrimaryKey[i], column.Name) || !column.NotNull || column.PrimaryKey ||
+			column.Unique || column.Default != "" || column.Check != "" || column.Generated != "" ||
+			column.Collation != "" || column.Identity != "" || column.References == nil ||
+			!strings.EqualFold(column.References.OnDelete, "cascade") || column.References.OnUpdate != "" {
+			return
+		}
+
+		referencedTable := r.structReference(column)
+		if referencedTable == nil || column.Name != referencedTable.Name+"_id" {
+			return
+		}
+		associated = append(associated, referencedTable)
+	}
+
+	source, target := associated[0], associated[1]
+	if source == target || table.Name != source.Name+"_"+target.Name {
+		return
+	}
+
+	r.associativeTables[table] = struct{}{}
+	r.associations[source] = append(r.associations[source], target)
+}
+
+// applyIndex records the index on its column, or as unexpressed if it is not a plain index of exactly one column.
+func (r *renderer) applyIndex(index *Index) error {
+	table := findTable(r.c.Tables, index.Table)
+	if table == nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("%w (table)", motmedelErrors.ErrNotInMap), index.Table)
+	}
+
+	if index.isPlain() {
+		column := table.column(index.Columns[0])
+		if column == nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("%w (column)", motmedelErrors.ErrNotInMap), index.Columns[0])
+		}
+
+		if index.Unique {
+			r.uniqueColumns[column] = struct{}{}
+		} else {
+			r.indexedColumns[column] = struct{}{}
+		}
+		return nil
+	}
+
+	statement := "CREATE INDEX"
+	if index.Unique {
+		statement = "CREATE UNIQUE INDEX"
+	}
+	statement += " ON " + table.Name
+	if index.Method != "" {
+		statement += " USING " + index.Method
+	}
+	indexed := index.Expression
+	if indexed == "" {
+		indexed = strings.Join(index.Columns, ", ")
+	}
+	statement += " (" + indexed + ")"
+	if index.Clauses != "" {
+		statement += " " + index.Clauses
+	}
+	r.unexpressed[table] = append(r.unexpressed[table], statement)
+
+	return nil
+}
+
+// pluralize returns the plural of an English noun, for the names of fields of slices.
+func pluralize(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "s"), strings.HasSuffix(noun, "x"), strings.HasSuffix(noun, "z"),
+		strings.HasSuffix(noun, "ch"), strings.HasSuffix(noun, "sh"):
+		return noun + "es"
+	case strings.HasSuffix(noun, "y") && len(noun) > 1 && !strings.ContainsAny(noun[len(noun)-2:len(noun)-1], "aeiou"):
+		return noun[:len(noun)-1] + "ies"
+	default:
+		return noun + "s"
+	}
+}
+
+// renderField renders the field of a column of a table, with a `postgres` tag whose options make the Postgres
+// producer generate the column definition.
+func (r *renderer) renderField(
+	table *Table,
+	column *Column,
+	compositeUnique []string,
+	usedFieldNames map[string]struct{},
+) string {
+	fieldName := go_source.ExportedIdentifier(column.Name)
+	if fieldName == "" {
+		fieldName = "Field"
+	}
+	fieldName = go_source.MakeUniqueName(fieldName, usedFieldNames)
+
+	primaryKey := column.PrimaryKey ||
+		(len(table.PrimaryKey) == 1 && strings.EqualFold(table.PrimaryKey[0], column.Name))
+	inPrimaryKey := primaryKey
+	for _, name := range table.PrimaryKey {
+		inPrimaryKey = inPrimaryKey || strings.EqualFold(name, column.Name)
+	}
+	nullable := !column.NotNull && !inPrimaryKey
+
+	_, unique := r.uniqueColumns[column]
+	unique = unique || column.Unique
+	for _, uniqueConstraint := range table.UniqueConstraints {
+		unique = unique || (len(uniqueConstraint) == 1 && strings.EqualFold(uniqueConstraint[0], column.Name))
+	}
+
+	var inCompositeUnique bool
+	for _, name := range compositeUnique {
+		inCompositeUnique = inCompositeUnique || strings.EqualFold(name, column.Name)
+	}
+
+	options := []string{column.Name}
+	if primaryKey {
+		options = append(options, "primarykey")
+	}
+	if nullable {
+		options = append(options, "nullable")
+	}
+	if unique {
+		options = append(options, "unique")
+	}
+	if inCompositeUnique {
+		options = append(options, "uniquecomposite")
+	}
+	if _, ok := r.indexedColumns[column]; ok {
+		options = append(options, "indexed")
+	}
+	if column.Default != "" {
+		options = append(options, "default:"+column.Default)
+	}
+	if column.Check != "" {
+		options = append(options, "check:"+column.Check)
+	}
+	if column.Generated != "" {
+		if column.GeneratedStored {
+			options = append(options, "generatedstored:"+column.Generated)
+		} else {
+			options = append(options, "generated:"+column.Generated)
+		}
+	}
+
+	var expression string
+	if referencedTable := r.structReference(column); referencedTable != nil {
+		expression = "*" + r.structNames[referencedTable]
+		if onDelete := column.References.OnDelete; onDelete != "" {
+			options = append(options, "ondelete:"+onDelete)
+		}
+		if onUpdate := column.References.OnUpdate; onUpdate != "" {
+			options = append(options, "onupdate:"+onUpdate)
+		}
+	} else {
+		fieldType := resolveGoType(column.Type)
+		if fieldType.importPath != "" {
+			r.imports[fieldType.importPath] = struct{}{}
+		}
+
+		expression = fieldType.expression
+		if nullable && !fieldType.nilable {
+			expression = "*" + expression
+		}
+
+		// The type option replaces the column type that the producer derives from the Go type, and thus includes the
+		// collation and the identity and reference clauses, for which there are no options.
+		typeString := column.Type
+		if column.Collation != "" {
+			typeString += " COLLATE " + column.Collation
+		}
+		if column.Identity != "" {
+			typeString += " " + column.Identity
+		}
+		if reference := column.References; reference != nil {
+			typeString += " REFERENCES " + reference.Table
+			if reference.Column != "" {
+				typeString += "(" + reference.Column + ")"
+			}
+			if reference.OnDelete != "" {
+				typeString += " ON DELETE " + reference.OnDelete
+			}
+			if reference.OnUpdate != "" {
+				typeString += " ON UPDATE " + reference.OnUpdate
+			}
+		}
+		if strings.ToLower(typeString) != fieldType.canonical {
+			options = append(options, "type:"+typeString)
+		}
+	}
+
+	return fmt.Sprintf(
+		"\t%s %s %s\n",
+		fieldName,
+		expression,
+		go_source.StructTag([2]string{"postgres", strings.Join(options, ",")}),
+	)
+}
+
+// renderStruct renders the struct type of a table, with a field per column, in order, and a field per associated
+// table. Constraints that tags cannot express are listed in the comment of the type.
+func (r *renderer) renderStruct(table *Table) string {
+	// The producer renders one composite unique constraint. Without one, a composite primary key, which the
+	// producer cannot render, is rendered as one, as it implies it.
+	var compositeUnique []string
+	unexpressed := r.unexpressed[table]
+	for _, uniqueConstraint := range table.UniqueConstraints {
+		switch {
+		case len(uniqueConstraint) == 1:
+		case compositeUnique == nil:
+			compositeUnique = uniqueConstraint
+		default:
+			unexpressed = append(unexpressed, fmt.Sprintf("UNIQUE (%s)", strings.Join(uniqueConstraint, ", ")))
+		}
+	}
+	if len(table.PrimaryKey) > 1 {
+		if compositeUnique == nil {
+			compositeUnique = table.PrimaryKey
+		}
+		unexpressed = append(unexpressed, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(table.PrimaryKey, ", ")))
+	}
+	for _, check := range table.Checks {
+		unexpressed = append(unexpressed, fmt.Sprintf("CHECK (%s)", check))
+	}
+
+	var builder strings.Builder
+	if len(unexpressed) > 0 {
+		builder.WriteString("// The table has constraints that the tags do not express:\n")
+		for _, constraint := range unexpressed {
+			builder.WriteString("//   - " + strings.Join(strings.Fields(constraint), " ") + "\n")
+		}
+	}
+
+	name := r.structNames[table]
+	builder.WriteString("type " + name + " struct {\n")
+
+	usedFieldNames := map[string]struct{}{}
+	for _, column := range table.Columns {
+		if isImplicitId(table, column) {
+			continue
+		}
+		builder.WriteString(r.renderField(table, column, compositeUnique, usedFieldNames))
+	}
+
+	for _, associatedTable := range r.associations[table] {
+		associatedName := r.structNames[associatedTable]
+		fieldName := go_source.MakeUniqueName(pluralize(associatedName), usedFieldNames)
+		builder.WriteString(fmt.Sprintf("\t%s []*%s\n", fieldName, associatedName))
+	}
+
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+// Render renders a Go struct type per table, in order, except for associative tables, which are rendered as fields
+// of slices of the associated types in the types of the first tables they refer to.
+func (c *Context) Render() (string, error) {
+	r := &renderer{
+		c:                 c,
+		structNames:       map[*Table]string{},
+		associations:      map[*Table][]*Table{},
+		associativeTables: map[*Table]struct{}{},
+		indexedColumns:    map[*Column]struct{}{},
+		uniqueColumns:     map[*Column]struct{}{},
+		unexpressed:       map[*Table][]string{},
+		imports:           map[string]struct{}{},
+	}
+
+	usedNames := map[string]struct{}{}
+	for _, table := range c.Tables {
+		structName := go_source.ExportedIdentifier(table.Name)
+		if structName == "" {
+			structName = "Table"
+		}
+		r.structNames[table] = go_source.MakeUniqueName(structName, usedNames)
+	}
+
+	for _, index := range c.Indices {
+		if err := r.applyIndex(index); err != nil {
+			return "", fmt.Errorf("apply index: %w", err)
+		}
+	}
+
+	for _, table := range c.Tables {
+		r.associate(table)
+	}
+
+	var declarations []string
+	for _, table := range c.Tables {
+		if _, ok := r.associativeTables[table]; ok {
+			continue
+		}
+		declarations = append(declarations, r.renderStruct(table))
+	}
+
+	source, err := go_source.Render(c.PackageName, r.imports, declarations)
+	if err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+
+	return source, nil
+}
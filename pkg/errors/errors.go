@@ -5,6 +5,18 @@ import (
 )
 
 var (
-	ErrNoStructField   = errors.New("no struct field")
-	ErrUnsupportedKind = errors.New("unsupported kind")
+	ErrNoStructField         = errors.New("no struct field")
+	ErrUnsupportedKind       = errors.New("unsupported kind")
+	ErrInvalidUnion          = errors.New("invalid union")
+	ErrInvalidOrdering       = errors.New("invalid ordering")
+	ErrNameCollision         = errors.New("name collision")
+	ErrInvalidNamingStrategy = errors.New("invalid naming strategy")
+	ErrInvalidTag            = errors.New("invalid tag")
+	ErrUnknownTagOption      = errors.New("unknown tag option")
+	ErrConflictingTagOptions = errors.New("conflicting tag options")
+	ErrInapplicableTagOption = errors.New("inapplicable tag option")
+	ErrInvalidDraft          = errors.New("invalid draft")
+	ErrUnsupportedValue      = errors.New("unsupported value")
+	ErrUnsupportedKeyword    = errors.New("unsupported keyword")
+	ErrUnsupportedName       = errors.New("unsupported name")
 )
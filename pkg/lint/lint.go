@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"reflect"
+	"slices"
+
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/vphpersson/type_generation/pkg/lint/types"
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	postgresTypes "github.com/vphpersson/type_generation/pkg/producers/postgres/types"
+	typescriptTypes "github.com/vphpersson/type_generation/pkg/producers/typescript/types"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+// Linter reports the problems of tags as the producers of its contexts interpret them, i.e. with their type mappings
+// and options, e.g. a `pattern` of a 64-bit integer field is applicable with jsonschema.Context.Int64AsString.
+type Linter struct {
+	JSONSchema *jsonschemaTypes.Context
+	Postgres   *postgresTypes.Context
+	TypeScript *typescriptTypes.Context
+}
+
+// New returns a linter with contexts with default options, which honor the type mappings registered with
+// context.RegisterTypeMapping.
+func New() *Linter {
+	return &Linter{
+		JSONSchema: &jsonschemaTypes.Context{Context: typeGenerationContext.New()},
+		Postgres:   &postgresTypes.Context{Context: typeGenerationContext.New()},
+		TypeScript: &typescriptTypes.Context{Context: typeGenerationContext.New()},
+	}
+}
+
+// LintField reports the problems of the tags of a field of the struct type: unknown options, malformed options,
+// options that conflict with each other, and constraints that do not apply to the type of the field.
+func (l *Linter) LintField(structType reflect.Type, field reflect.StructField) []types.Issue {
+	var issues []types.Issue
+
+	for _, linter := range []struct {
+		tagKey string
+		lint   func(reflect.StructField) error
+	}{
+		{"jsonschema", l.JSONSchema.LintTag},
+		{"postgres", l.Postgres.LintTag},
+		{"typescript", l.TypeScript.LintTag},
+	} {
+		err := linter.lint(field)
+		if err == nil {
+			continue
+		}
+
+		// Report each problem of the tag as a separate issue.
+		errs := []error{err}
+		if joinedErr, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joinedErr.Unwrap()
+		}
+		for _, err := range errs {
+			issues = append(
+				issues,
+				types.Issue{StructType: structType, FieldName: field.Name, TagKey: linter.tagKey, Err: err},
+			)
+		}
+	}
+
+	return issues
+}
+
+func (l *Linter) lintType(reflectType reflect.Type, visited map[reflect.Type]struct{}) []types.Issue {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+	if _, ok := visited[reflectType]; ok {
+		return nil
+	}
+	visited[reflectType] = struct{}{}
+
+	switch reflectType.Kind() {
+	case reflect.Struct:
+		var issues []types.Issue
+		for i := range reflectType.NumField() {
+			field := reflectType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			issues = append(issues, l.LintField(reflectType, field)...)
+			issues = append(issues, l.lintType(field.Type, visited)...)
+		}
+		return issues
+	case reflect.Slice, reflect.Array:
+		return l.lintType(reflectType.Elem(), visited)
+	case reflect.Map:
+		return slices.Concat(l.lintType(reflectType.Key(), visited), l.lintType(reflectType.Elem(), visited))
+	default:
+		return nil
+	}
+}
+
+// Lint reports the problems of the tags of the fields of the provided values' types, and of the struct types that
+// they reference. The values are handled as by context.Context.Add.
+func (l *Linter) Lint(values ...any) []types.Issue {
+	visited := map[reflect.Type]struct{}{}
+
+	var issues []types.Issue
+	for _, value := range values {
+		var reflectType reflect.Type
+		switch v := value.(type) {
+		case reflect.Type:
+			reflectType = v
+		case reflect.Value:
+			reflectType = v.Type()
+		default:
+			reflectType = reflect.TypeOf(v)
+		}
+		if reflectType == nil {
+			continue
+		}
+
+		issues = append(issues, l.lintType(reflectType, visited)...)
+	}
+
+	return issues
+}
+
+// LintField reports the problems of the tags of a field of the struct type, as interpreted by producers with default
+// options.
+func LintField(structType reflect.Type, field reflect.StructField) []types.Issue {
+	return New().LintField(structType, field)
+}
+
+// Lint reports the problems of the tags of the fields of the provided values' types, and of the struct types that
+// they reference, as interpreted by producers with default options.
+func Lint(values ...any) []types.Issue {
+	return New().Lint(values...)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Issue describes a problem with a tag of a struct field.
+type Issue struct {
+	// StructType is the struct type that declares the field.
+	StructType reflect.Type
+	// FieldName is the Go name of the field.
+	FieldName string
+	// TagKey is the key of the tag, e.g. "jsonschema".
+	TagKey string
+	Err    error
+}
+
+func (i Issue) Error() string {
+	return fmt.Sprintf("%s.%s: %s tag: %v", i.StructType, i.FieldName, i.TagKey, i.Err)
+}
+
+func (i Issue) Unwrap() error {
+	return i.Err
+}
@@ -0,0 +1,24 @@
+package avro
+
+import (
+	"fmt"
+	"reflect"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/avro/types"
+	typeGenerationTypesContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+func Convert(root reflect.Type) (string, error) {
+	avroContext := types.Context{Context: typeGenerationTypesContext.New()}
+	if err := avroContext.Add(root); err != nil {
+		return "", fmt.Errorf("add: %w", err)
+	}
+
+	output, err := avroContext.RenderRoot(root)
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("render: %w", err), avroContext)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,372 @@
+package compatibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	avroErrors "github.com/vphpersson/type_generation/pkg/producers/avro/errors"
+)
+
+// Incompatibility describes a change that prevents data written with the previous schema from being read with the
+// current schema.
+type Incompatibility struct {
+	// Path is the location of the change, as a dot-separated list of field names from the root.
+	Path    string
+	Message string
+}
+
+func (i *Incompatibility) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+type field struct {
+	name       string
+	aliases    []string
+	schema     *schema
+	hasDefault bool
+}
+
+type schema struct {
+	// typ is a primitive type name, one of the complex type names, or "union" or "reference".
+	typ         string
+	fullName    string
+	aliases     []string
+	fields      []*field
+	symbols     []string
+	enumDefault string
+	items       *schema
+	values      *schema
+	branches    []*schema
+	size        int
+	logicalType string
+}
+
+var primitiveTypes = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true, "double": true, "bytes": true,
+	"string": true,
+}
+
+// promotions maps a writer's primitive type to the reader types it may be promoted to, per the Avro specification.
+var promotions = map[string][]string{
+	"int":    {"long", "float", "double"},
+	"long":   {"float", "double"},
+	"float":  {"double"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+type parser struct {
+	namedTypes map[string]*schema
+}
+
+func qualify(name string, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (p *parser) parse(value any, namespace string) (*schema, error) {
+	switch v := value.(type) {
+	case string:
+		if primitiveTypes[v] {
+			return &schema{typ: v}, nil
+		}
+		return &schema{typ: "reference", fullName: qualify(v, namespace)}, nil
+	case []any:
+		union := &schema{typ: "union"}
+		for _, branch := range v {
+			branchSchema, err := p.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union.branches = append(union.branches, branchSchema)
+		}
+		return union, nil
+	case map[string]any:
+		typ, ok := v["type"].(string)
+		if !ok {
+			// E.g. `{"type": {"type": "array", ...}}`.
+			return p.parse(v["type"], namespace)
+		}
+		logicalType, _ := v["logicalType"].(string)
+
+		switch typ {
+		case "record", "error", "enum", "fixed":
+		case "array":
+			items, err := p.parse(v["items"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			return &schema{typ: typ, items: items, logicalType: logicalType}, nil
+		case "map":
+			values, err := p.parse(v["values"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			return &schema{typ: typ, values: values, logicalType: logicalType}, nil
+		default:
+			if primitiveTypes[typ] {
+				return &schema{typ: typ, logicalType: logicalType}, nil
+			}
+			return &schema{typ: "reference", fullName: qualify(typ, namespace)}, nil
+		}
+
+		name, _ := v["name"].(string)
+		if name == "" {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: named type without name", avroErrors.ErrInvalidSchema))
+		}
+		if explicitNamespace, ok := v["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = explicitNamespace
+		}
+		named := &schema{typ: typ, fullName: qualify(name, namespace), logicalType: logicalType}
+		if i := strings.LastIndex(named.fullName, "."); i >= 0 {
+			namespace = named.fullName[:i]
+		}
+		if aliases, ok := v["aliases"].([]any); ok {
+			for _, alias := range aliases {
+				if aliasString, ok := alias.(string); ok {
+					named.aliases = append(named.aliases, qualify(aliasString, namespace))
+				}
+			}
+		}
+		// Register before descending into the fields so that recursive references resolve.
+		p.namedTypes[named.fullName] = named
+
+		switch typ {
+		case "enum":
+			symbols, _ := v["symbols"].([]any)
+			for _, symbol := range symbols {
+				if symbolString, ok := symbol.(string); ok {
+					named.symbols = append(named.symbols, symbolString)
+				}
+			}
+			named.enumDefault, _ = v["default"].(string)
+		case "fixed":
+			size, _ := v["size"].(float64)
+			named.size = int(size)
+		default:
+			fields, _ := v["fields"].([]any)
+			for _, rawField := range fields {
+				fieldMap, ok := rawField.(map[string]any)
+				if !ok {
+					return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: field not an object", avroErrors.ErrInvalidSchema))
+				}
+
+				fieldName, _ := fieldMap["name"].(string)
+				fieldSchema, err := p.parse(fieldMap["type"], namespace)
+				if err != nil {
+					return nil, err
+				}
+
+				_, hasDefault := fieldMap["default"]
+				recordField := &field{name: fieldName, schema: fieldSchema, hasDefault: hasDefault}
+				if aliases, ok := fieldMap["aliases"].([]any); ok {
+					for _, alias := range aliases {
+						if aliasString, ok := alias.(string); ok {
+							recordField.aliases = append(recordField.aliases, aliasString)
+						}
+					}
+				}
+				named.fields = append(named.fields, recordField)
+			}
+		}
+
+		return named, nil
+	default:
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: unexpected %T", avroErrors.ErrInvalidSchema, value))
+	}
+}
+
+func (p *parser) resolve(s *schema) (*schema, error) {
+	if s.typ != "reference" {
+		return s, nil
+	}
+
+	named, ok := p.namedTypes[s.fullName]
+	if !ok {
+		return nil, motmedelErrors.NewWithTrace(avroErrors.ErrUnknownNamedType, s.fullName)
+	}
+
+	return named, nil
+}
+
+func shortName(fullName string) string {
+	return fullName[strings.LastIndex(fullName, ".")+1:]
+}
+
+// namesMatch reports whether a reader's named type may be used to read a writer's, by unqualified name or alias.
+func namesMatch(reader *schema, writer *schema) bool {
+	if shortName(reader.fullName) == shortName(writer.fullName) {
+		return true
+	}
+	return slices.ContainsFunc(reader.aliases, func(alias string) bool {
+		return shortName(alias) == shortName(writer.fullName)
+	})
+}
+
+type checker struct {
+	reader, writer *parser
+	// visited contains record pairs already being checked, which terminates recursive types.
+	visited           map[[2]*schema]struct{}
+	incompatibilities []*Incompatibility
+}
+
+func (c *checker) report(path string, format string, args ...any) {
+	c.incompatibilities = append(c.incompatibilities, &Incompatibility{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func describe(s *schema) string {
+	if s.fullName != "" {
+		return fmt.Sprintf("%s %s", s.typ, s.fullName)
+	}
+	return s.typ
+}
+
+// matches reports whether reader can read writer's data, without reporting anything.
+func (c *checker) matches(reader *schema, writer *schema) bool {
+	sub := &checker{reader: c.reader, writer: c.writer, visited: maps.Clone(c.visited)}
+	if err := sub.check("", reader, writer); err != nil {
+		return false
+	}
+	return len(sub.incompatibilities) == 0
+}
+
+func (c *checker) check(path string, reader *schema, writer *schema) error {
+	reader, err := c.reader.resolve(reader)
+	if err != nil {
+		return fmt.Errorf("resolve (reader): %w", err)
+	}
+	writer, err = c.writer.resolve(writer)
+	if err != nil {
+		return fmt.Errorf("resolve (writer): %w", err)
+	}
+
+	if writer.typ == "union" {
+		for _, branch := range writer.branches {
+			if err := c.check(path, reader, branch); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if reader.typ == "union" {
+		for _, branch := range reader.branches {
+			if c.matches(branch, writer) {
+				return nil
+			}
+		}
+		c.report(path, "%s is not in the union", describe(writer))
+		return nil
+	}
+
+	if reader.typ != writer.typ {
+		if slices.Contains(promotions[writer.typ], reader.typ) {
+			return nil
+		}
+		c.report(path, "%s cannot be read as %s", describe(writer), describe(reader))
+		return nil
+	}
+
+	if reader.logicalType != writer.logicalType {
+		c.report(path, "logical type changed from %q to %q", writer.logicalType, reader.logicalType)
+	}
+
+	switch reader.typ {
+	case "array":
+		return c.check(path, reader.items, writer.items)
+	case "map":
+		return c.check(path, reader.values, writer.values)
+	case "fixed":
+		if !namesMatch(reader, writer) {
+			c.report(path, "name changed from %s to %s", writer.fullName, reader.fullName)
+		}
+		if reader.size != writer.size {
+			c.report(path, "size changed from %d to %d", writer.size, reader.size)
+		}
+	case "enum":
+		if !namesMatch(reader, writer) {
+			c.report(path, "name changed from %s to %s", writer.fullName, reader.fullName)
+		}
+		if reader.enumDefault == "" {
+			for _, symbol := range writer.symbols {
+				if !slices.Contains(reader.symbols, symbol) {
+					c.report(path, "symbol %q was removed without an enum default", symbol)
+				}
+			}
+		}
+	case "record", "error":
+		if !namesMatch(reader, writer) {
+			c.report(path, "name changed from %s to %s", writer.fullName, reader.fullName)
+		}
+
+		key := [2]*schema{reader, writer}
+		if _, ok := c.visited[key]; ok {
+			return nil
+		}
+		c.visited[key] = struct{}{}
+
+		for _, readerField := range reader.fields {
+			fieldPath := readerField.name
+			if path != "" {
+				fieldPath = path + "." + readerField.name
+			}
+
+			index := slices.IndexFunc(writer.fields, func(writerField *field) bool {
+				return writerField.name == readerField.name || slices.Contains(readerField.aliases, writerField.name)
+			})
+			if index == -1 {
+				if !readerField.hasDefault {
+					c.report(fieldPath, "field was added without a default")
+				}
+				continue
+			}
+
+			if err := c.check(fieldPath, readerField.schema, writer.fields[index].schema); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Check reports the changes in the current schema that make it unable to read data written with the previous schema,
+// i.e. that violate backward compatibility as defined by the Avro schema resolution rules. A nil result means that
+// the current schema is backward compatible.
+func Check(previousSchema string, currentSchema string) ([]*Incompatibility, error) {
+	var previousValue, currentValue any
+	if err := json.Unmarshal([]byte(previousSchema), &previousValue); err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (previous schema): %w", err))
+	}
+	if err := json.Unmarshal([]byte(currentSchema), &currentValue); err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (current schema): %w", err))
+	}
+
+	writer := &parser{namedTypes: map[string]*schema{}}
+	writerSchema, err := writer.parse(previousValue, "")
+	if err != nil {
+		return nil, fmt.Errorf("parse (previous schema): %w", err)
+	}
+
+	reader := &parser{namedTypes: map[string]*schema{}}
+	readerSchema, err := reader.parse(currentValue, "")
+	if err != nil {
+		return nil, fmt.Errorf("parse (current schema): %w", err)
+	}
+
+	c := &checker{reader: reader, writer: writer, visited: map[[2]*schema]struct{}{}}
+	if err := c.check("", readerSchema, writerSchema); err != nil {
+		return nil, fmt.Errorf("check: %w", err)
+	}
+
+	return c.incompatibilities, nil
+}
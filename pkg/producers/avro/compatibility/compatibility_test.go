@@ -0,0 +1,151 @@
+package compatibility_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/vphpersson/type_generation/pkg/producers/avro/compatibility"
+)
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name              string
+		previousSchema    string
+		currentSchema     string
+		incompatibilities []string
+	}{
+		{
+			name:           "unchanged",
+			previousSchema: `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			currentSchema:  `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+		},
+		{
+			name:           "promotion",
+			previousSchema: `{"type":"record","name":"R","fields":[{"name":"a","type":"int"},{"name":"b","type":"string"}]}`,
+			currentSchema:  `{"type":"record","name":"R","fields":[{"name":"a","type":"double"},{"name":"b","type":"bytes"}]}`,
+		},
+		{
+			name:              "narrowing",
+			previousSchema:    `{"type":"record","name":"R","fields":[{"name":"a","type":"long"}]}`,
+			currentSchema:     `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			incompatibilities: []string{"a: long cannot be read as int"},
+		},
+		{
+			name:           "field added with default",
+			previousSchema: `{"type":"record","name":"R","fields":[]}`,
+			currentSchema:  `{"type":"record","name":"R","fields":[{"name":"a","type":["null","int"],"default":null}]}`,
+		},
+		{
+			name:              "field added without default",
+			previousSchema:    `{"type":"record","name":"R","fields":[]}`,
+			currentSchema:     `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			incompatibilities: []string{"a: field was added without a default"},
+		},
+		{
+			name:           "field removed",
+			previousSchema: `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			currentSchema:  `{"type":"record","name":"R","fields":[]}`,
+		},
+		{
+			name:           "field renamed with alias",
+			previousSchema: `{"type":"record","name":"R","fields":[{"name":"a","type":"int"}]}`,
+			currentSchema:  `{"type":"record","name":"R","fields":[{"name":"b","aliases":["a"],"type":"int"}]}`,
+		},
+		{
+			name:           "record renamed with alias",
+			previousSchema: `{"type":"record","name":"R","namespace":"n","fields":[]}`,
+			currentSchema:  `{"type":"record","name":"S","namespace":"n","aliases":["R"],"fields":[]}`,
+		},
+		{
+			name:              "record renamed without alias",
+			previousSchema:    `{"type":"record","name":"R","namespace":"n","fields":[]}`,
+			currentSchema:     `{"type":"record","name":"S","namespace":"n","fields":[]}`,
+			incompatibilities: []string{"name changed from n.R to n.S"},
+		},
+		{
+			name:              "writer union branch unreadable",
+			previousSchema:    `["null","string"]`,
+			currentSchema:     `"string"`,
+			incompatibilities: []string{"null cannot be read as string"},
+		},
+		{
+			name:           "reader union with promotion",
+			previousSchema: `"int"`,
+			currentSchema:  `["null","long"]`,
+		},
+		{
+			name:              "reader union without match",
+			previousSchema:    `"boolean"`,
+			currentSchema:     `["null","string"]`,
+			incompatibilities: []string{"boolean is not in the union"},
+		},
+		{
+			name:           "writer and reader unions",
+			previousSchema: `["null","int"]`,
+			currentSchema:  `["null","int","string"]`,
+		},
+		{
+			name:              "enum symbol removed without default",
+			previousSchema:    `{"type":"enum","name":"E","symbols":["A","B"]}`,
+			currentSchema:     `{"type":"enum","name":"E","symbols":["A"]}`,
+			incompatibilities: []string{`symbol "B" was removed without an enum default`},
+		},
+		{
+			name:           "enum symbol removed with default",
+			previousSchema: `{"type":"enum","name":"E","symbols":["A","B"]}`,
+			currentSchema:  `{"type":"enum","name":"E","symbols":["A"],"default":"A"}`,
+		},
+		{
+			name:              "fixed size changed",
+			previousSchema:    `{"type":"fixed","name":"F","size":4}`,
+			currentSchema:     `{"type":"fixed","name":"F","size":8}`,
+			incompatibilities: []string{"size changed from 4 to 8"},
+		},
+		{
+			name: "nested record",
+			previousSchema: `{"type":"record","name":"R","fields":[` +
+				`{"name":"inner","type":{"type":"record","name":"I","fields":[]}}]}`,
+			currentSchema: `{"type":"record","name":"R","fields":[` +
+				`{"name":"inner","type":{"type":"record","name":"I","fields":[{"name":"x","type":"int"}]}}]}`,
+			incompatibilities: []string{"inner.x: field was added without a default"},
+		},
+		{
+			name: "recursive record",
+			previousSchema: `{"type":"record","name":"Node","fields":[` +
+				`{"name":"value","type":"int"},{"name":"next","type":["null","Node"],"default":null}]}`,
+			currentSchema: `{"type":"record","name":"Node","fields":[` +
+				`{"name":"value","type":"long"},{"name":"next","type":["null","Node"],"default":null}]}`,
+		},
+		{
+			name: "recursive record with incompatible change",
+			previousSchema: `{"type":"record","name":"Node","fields":[` +
+				`{"name":"value","type":"long"},{"name":"next","type":["null","Node"],"default":null}]}`,
+			currentSchema: `{"type":"record","name":"Node","fields":[` +
+				`{"name":"value","type":"int"},{"name":"next","type":["null","Node"],"default":null}]}`,
+			incompatibilities: []string{"value: long cannot be read as int"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			incompatibilities, err := compatibility.Check(testCase.previousSchema, testCase.currentSchema)
+			if err != nil {
+				t.Fatalf("check: %v", err)
+			}
+
+			var messages []string
+			for _, incompatibility := range incompatibilities {
+				messages = append(messages, incompatibility.String())
+			}
+			if !slices.Equal(messages, testCase.incompatibilities) {
+				t.Errorf("got %q, want %q", messages, testCase.incompatibilities)
+			}
+		})
+	}
+}
+
+func TestCheckUnknownNamedType(t *testing.T) {
+	if _, err := compatibility.Check(`"Missing"`, `"string"`); err == nil {
+		t.Error("got no error for a reference to an unknown named type")
+	}
+}
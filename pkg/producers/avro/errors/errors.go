@@ -0,0 +1,13 @@
+package errors
+
+import "errors"
+
+var (
+	ErrGenericTypesUnsupported  = errors.New("generic types unsupported")
+	ErrUnsupportedMapKey        = errors.New("unsupported map key")
+	ErrJSONMarshalerUnsupported = errors.New("json marshaler unsupported")
+	ErrInvalidSchema            = errors.New("invalid schema")
+	ErrUnknownNamedType         = errors.New("unknown named type")
+	ErrInvalidName              = errors.New("invalid name")
+	ErrUnsignedOverflow         = errors.New("unsigned integer overflows long")
+)
@@ -0,0 +1,58 @@
+package types
+
+import "encoding/json"
+
+// Schema is an Avro schema: a primitive type name or a reference to a previously defined named type (string), a
+// Union, or one of the complex types below.
+type Schema any
+
+const (
+	Null    = "null"
+	Boolean = "boolean"
+	Int     = "int"
+	Long    = "long"
+	Float   = "float"
+	Double  = "double"
+	Bytes   = "bytes"
+	String  = "string"
+)
+
+var nullDefault = json.RawMessage("null")
+
+type Union []Schema
+
+type Record struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Fields    []*Field `json:"fields"`
+}
+
+type Field struct {
+	Name string `json:"name"`
+	Type Schema `json:"type"`
+	// Default is omitted if nil. A `null` default is expressed as a pointer to a raw `null` message.
+	Default *json.RawMessage `json:"default,omitempty"`
+}
+
+type Enum struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Symbols   []string `json:"symbols"`
+}
+
+type Array struct {
+	Type  string `json:"type"`
+	Items Schema `json:"items"`
+}
+
+type Map struct {
+	Type   string `json:"type"`
+	Values Schema `json:"values"`
+}
+
+type LogicalType struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+}
@@ -0,0 +1,323 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/Motmedel/utils_go/pkg/errors/types/nil_error"
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/Motmedel/utils_go/pkg/utils"
+	"github.com/vphpersson/type_generation/internal/enum_values"
+	"github.com/vphpersson/type_generation/internal/package_files"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	avroErrors "github.com/vphpersson/type_generation/pkg/producers/avro/errors"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+var invalidNameCharacters = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// namespaceFromPkgPath derives an Avro namespace from a Go package path, e.g. "github.com/acme/api-types" ->
+// "github.com.acme.api_types".
+func namespaceFromPkgPath(pkgPath string) string {
+	var components []string
+	for _, component := range strings.FieldsFunc(pkgPath, func(r rune) bool { return r == '/' || r == '.' }) {
+		component = invalidNameCharacters.ReplaceAllString(component, "_")
+		if !namePattern.MatchString(component) {
+			component = "_" + component
+		}
+		components = append(components, component)
+	}
+
+	return strings.Join(components, ".")
+}
+
+func fullName(namespace string, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func isTime(t reflect.Type) bool {
+	return t.Name() == "Time" && t.PkgPath() == "time"
+}
+
+type Context struct {
+	*typeGenerationContext.Context
+
+	enumSymbols      map[reflect.Type][]string
+	definedFullNames map[string]struct{}
+}
+
+// getEnumSymbols returns the values of the string constants declared with the type if they are all valid Avro enum
+// symbols, and nil otherwise, including when the source of the package of the type cannot be found.
+func (c *Context) getEnumSymbols(reflectType reflect.Type) ([]string, error) {
+	if c.enumSymbols == nil {
+		c.enumSymbols = map[reflect.Type][]string{}
+	}
+
+	if symbols, ok := c.enumSymbols[reflectType]; ok {
+		return symbols, nil
+	}
+
+	values, err := enum_values.GetEnumValues(reflectType)
+	if err != nil {
+		// Without the source of the package, the type is rendered as a string.
+		if !errors.Is(err, package_files.ErrPackageNotFound) {
+			return nil, motmedelErrors.New(fmt.Errorf("get enum values: %w", err), reflectType)
+		}
+		values = nil
+	}
+
+	for _, value := range values {
+		if !namePattern.MatchString(value) {
+			values = nil
+			break
+		}
+	}
+
+	c.enumSymbols[reflectType] = values
+
+	return values, nil
+}
+
+// define reports whether the named type has not been defined before in the schema being rendered, and marks it as
+// defined. Avro requires a named type to be defined exactly once; later uses must reference it by its full name.
+func (c *Context) define(fullName string) bool {
+	if _, ok := c.definedFullNames[fullName]; ok {
+		return false
+	}
+	c.definedFullNames[fullName] = struct{}{}
+	return true
+}
+
+// GetAvroType returns an Avro schema describing the provided type.
+func (c *Context) GetAvroType(reflectType reflect.Type) (Schema, error) {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.Avro != nil {
+		return typeMapping.Avro, nil
+	}
+
+	if !isTime(reflectType) {
+		switch typeGenerationContext.GetMarshalerKind(reflectType) {
+		case typeGenerationContext.MarshalerKindText:
+			return String, nil
+		case typeGenerationContext.MarshalerKindJSON:
+			// The encoding is unknown, and Avro has no type accepting any value.
+			return nil, motmedelErrors.NewWithTrace(avroErrors.ErrJSONMarshalerUnsupported, reflectType)
+		default:
+		}
+	}
+
+	switch kind := reflectType.Kind(); kind {
+	case reflect.Struct:
+		if isTime(reflectType) {
+			return &LogicalType{Type: Long, LogicalType: "timestamp-micros"}, nil
+		}
+
+		typeDeclaration, err := utils.MapGetNonZero(c.TypeDeclarations, reflectType)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("map get non zero: %w", err), c.TypeDeclarations, reflectType)
+		}
+
+		interfaceDeclaration, err := utils.Convert[*type_declaration.InterfaceDeclaration](typeDeclaration)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("convert: %w", err), typeDeclaration)
+		}
+
+		if interfaceDeclaration.GenericTypeInfo != nil {
+			return nil, motmedelErrors.NewWithTrace(avroErrors.ErrGenericTypesUnsupported, reflectType)
+		}
+
+		namespace := namespaceFromPkgPath(reflectType.PkgPath())
+		if !c.define(fullName(namespace, interfaceDeclaration.Identifier)) {
+			return fullName(namespace, interfaceDeclaration.Identifier), nil
+		}
+
+		record, err := c.buildRecordSchema(interfaceDeclaration, namespace)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("build record schema: %w", err), interfaceDeclaration)
+		}
+
+		return record, nil
+	case reflect.Bool:
+		return Boolean, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return Int, nil
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return Long, nil
+	case reflect.Uint, reflect.Uint64:
+		// Avro has no unsigned types, and values above the maximum long would overflow; a type mapping can choose
+		// another representation, e.g. a string or a decimal.
+		return nil, motmedelErrors.NewWithTrace(avroErrors.ErrUnsignedOverflow, reflectType)
+	case reflect.Float32:
+		return Float, nil
+	case reflect.Float64:
+		return Double, nil
+	case reflect.String:
+		if reflectType.Name() == "" || reflectType.PkgPath() == "" {
+			return String, nil
+		}
+
+		symbols, err := c.getEnumSymbols(reflectType)
+		if err != nil {
+			return nil, fmt.Errorf("get enum symbols: %w", err)
+		}
+		if len(symbols) == 0 {
+			return String, nil
+		}
+
+		name := reflectType.Name()
+		if typeDeclaration, ok := c.TypeDeclarations[reflectType]; ok {
+			name = typeDeclaration.QualifiedName()
+		}
+
+		namespace := namespaceFromPkgPath(reflectType.PkgPath())
+		if !c.define(fullName(namespace, name)) {
+			return fullName(namespace, name), nil
+		}
+
+		return &Enum{Type: "enum", Name: name, Namespace: namespace, Symbols: symbols}, nil
+	case reflect.Slice, reflect.Array:
+		if kind == reflect.Slice && reflectType.Elem().Kind() == reflect.Uint8 {
+			return Bytes, nil
+		}
+
+		items, err := c.GetAvroType(reflectType.Elem())
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("get avro type (items): %w", err), reflectType.Elem())
+		}
+
+		return &Array{Type: "array", Items: items}, nil
+	case reflect.Map:
+		// Avro map keys are always strings.
+		isTextMarshaler := typeGenerationContext.GetMarshalerKind(reflectType.Key()) == typeGenerationContext.MarshalerKindText
+		if keyKind := reflectType.Key().Kind(); keyKind != reflect.String && !isTextMarshaler {
+			return nil, motmedelErrors.NewWithTrace(avroErrors.ErrUnsupportedMapKey, keyKind)
+		}
+
+		values, err := c.GetAvroType(reflectType.Elem())
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("get avro type (map values): %w", err), reflectType.Elem())
+		}
+
+		return &Map{Type: "map", Values: values}, nil
+	default:
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind,
+		)
+	}
+}
+
+// buildRecordSchema builds the record schema for a given interface declaration.
+func (c *Context) buildRecordSchema(
+	interfaceDeclaration *type_declaration.InterfaceDeclaration,
+	namespace string,
+) (*Record, error) {
+	if !namePattern.MatchString(interfaceDeclaration.Identifier) {
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: record name %q", avroErrors.ErrInvalidName, interfaceDeclaration.Identifier),
+			interfaceDeclaration,
+		)
+	}
+
+	record := &Record{
+		Type:      "record",
+		Name:      interfaceDeclaration.Identifier,
+		Namespace: namespace,
+		Fields:    []*Field{},
+	}
+
+	for _, property := range interfaceDeclaration.Properties {
+		if property == nil {
+			continue
+		}
+
+		field := property.Field
+		if field == nil {
+			return nil, motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
+		}
+
+		identifier := c.PropertyNameConvention.Apply(property.Identifier)
+		nullable := property.Optional || field.Type.Kind() == reflect.Pointer
+
+		// The `avro` tag, used by the common Go Avro libraries, only carries a name.
+		if avroTag := strings.TrimSpace(field.Tag.Get("avro")); avroTag != "" {
+			if avroTag == "-" {
+				continue
+			}
+			identifier = avroTag
+		} else {
+			jsonTag := motmedelJsonTag.New(field.Tag.Get("json"))
+			if jsonTag != nil {
+				if jsonTag.Skip {
+					continue
+				}
+
+				if name := jsonTag.Name; name != "" {
+					identifier = name
+				}
+
+				nullable = nullable || jsonTag.OmitEmpty || jsonTag.OmitZero
+			}
+		}
+
+		if !namePattern.MatchString(identifier) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: field name %q", avroErrors.ErrInvalidName, identifier),
+				interfaceDeclaration.Identifier,
+			)
+		}
+
+		fieldType := field.Type
+		fieldSchema, err := c.GetAvroType(fieldType)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("get avro type: %w", err), fieldType)
+		}
+
+		recordField := &Field{Name: identifier, Type: fieldSchema}
+		if nullable {
+			// The default value of a union must match its first branch, thus "null" goes first.
+			recordField.Type = Union{Null, fieldSchema}
+			recordField.Default = &nullDefault
+		}
+
+		record.Fields = append(record.Fields, recordField)
+	}
+
+	return record, nil
+}
+
+// RenderRoot builds an Avro schema with the provided root type as the top-level record. Named types are defined
+// inline at their first use and referenced by their full name thereafter.
+func (c *Context) RenderRoot(root reflect.Type) (string, error) {
+	root = motmedelReflect.RemoveIndirection(root)
+
+	rootKind := root.Kind()
+	if rootKind != reflect.Struct {
+		return "", motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, rootKind)
+	}
+
+	c.definedFullNames = map[string]struct{}{}
+
+	schema, err := c.GetAvroType(root)
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("get avro type (root type): %w", err), root)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return "", motmedelErrors.NewWithTrace(fmt.Errorf("json marshal (schema): %w", err), schema)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,23 @@
+package csharp
+
+import (
+	"fmt"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/csharp/types"
+	typeGenerationTypesContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+func Convert(namespace string, values ...any) (string, error) {
+	csharpContext := types.Context{Context: typeGenerationTypesContext.New(), Namespace: namespace}
+	if err := csharpContext.Add(values...); err != nil {
+		return "", fmt.Errorf("add: %w", err)
+	}
+
+	output, err := csharpContext.Render()
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("render: %w", err), csharpContext)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Type interface {
+	String() (string, error)
+}
+
+type TypeDeclaration interface {
+	TypeReference() *TypeReference
+	QualifiedName() string
+}
+
+type TypeReference struct {
+	TypeDeclaration TypeDeclaration
+	TypeArguments   []Type
+}
+
+func (t *TypeReference) String() (string, error) {
+	name := t.TypeDeclaration.QualifiedName()
+	if len(t.TypeArguments) == 0 {
+		return name, nil
+	}
+
+	args := make([]string, 0, len(t.TypeArguments))
+	for _, a := range t.TypeArguments {
+		typeStr, err := a.String()
+		if err != nil {
+			return "", fmt.Errorf("type string: %w", err)
+		}
+		args = append(args, typeStr)
+	}
+
+	return fmt.Sprintf("%s<%s>", name, strings.Join(args, ", ")), nil
+}
+
+type TypeParameter struct {
+	Identifier string
+}
+
+func (p *TypeParameter) String() (string, error) { return p.Identifier, nil }
+
+type BasicType string
+
+const (
+	Bool           = BasicType("bool")
+	SByte          = BasicType("sbyte")
+	Byte           = BasicType("byte")
+	Short          = BasicType("short")
+	UShort         = BasicType("ushort")
+	Int            = BasicType("int")
+	UInt           = BasicType("uint")
+	Long           = BasicType("long")
+	ULong          = BasicType("ulong")
+	Float          = BasicType("float")
+	Double         = BasicType("double")
+	String         = BasicType("string")
+	ByteArray      = BasicType("byte[]")
+	DateTimeOffset = BasicType("DateTimeOffset")
+	JsonElement    = BasicType("JsonElement")
+)
+
+func (b BasicType) String() (string, error) { return string(b), nil }
+
+type NullableType struct {
+	Type Type
+}
+
+func (n *NullableType) String() (string, error) {
+	typeStr, err := n.Type.String()
+	if err != nil {
+		return "", fmt.Errorf("type string: %w", err)
+	}
+
+	return typeStr + "?", nil
+}
+
+type ListType struct {
+	ItemsType Type
+}
+
+func (l *ListType) String() (string, error) {
+	typeStr, err := l.ItemsType.String()
+	if err != nil {
+		return "", fmt.Errorf("items type string: %w", err)
+	}
+
+	return fmt.Sprintf("List<%s>", typeStr), nil
+}
+
+type DictionaryType struct {
+	KeyType   Type
+	ValueType Type
+}
+
+func (d *DictionaryType) String() (string, error) {
+	keyTypeString, err := d.KeyType.String()
+	if err != nil {
+		return "", fmt.Errorf("key type string: %w", err)
+	}
+
+	valueTypeString, err := d.ValueType.String()
+	if err != nil {
+		return "", fmt.Errorf("value type string: %w", err)
+	}
+
+	return fmt.Sprintf("Dictionary<%s, %s>", keyTypeString, valueTypeString), nil
+}
@@ -0,0 +1,368 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Motmedel/utils_go/pkg/errors/types/nil_error"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+	"github.com/vphpersson/type_generation/pkg/types/shape"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/Motmedel/utils_go/pkg/utils"
+	jsonschemaTag "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+)
+
+func isTime(t reflect.Type) bool {
+	return t.Name() == "Time" && t.PkgPath() == "time"
+}
+
+// hasTypeMapping reports whether the type mapping has a C# representation, in which case the mapped type is not
+// rendered as a declaration.
+func hasTypeMapping(typeMapping *type_mapping.TypeMapping) bool {
+	return typeMapping.CSharp != ""
+}
+
+type Context struct {
+	*typeGenerationContext.Context
+	// Namespace is the file-scoped namespace of the rendered records. No namespace is declared if empty.
+	Namespace string
+}
+
+func (c *Context) GetCSharpType(reflectType reflect.Type) (Type, error) {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.CSharp != "" {
+		return BasicType(typeMapping.CSharp), nil
+	}
+
+	if !isTime(reflectType) {
+		switch typeGenerationContext.GetMarshalerKind(reflectType) {
+		case typeGenerationContext.MarshalerKindText:
+			return String, nil
+		case typeGenerationContext.MarshalerKindJSON:
+			return JsonElement, nil
+		default:
+		}
+	}
+
+	switch kind := reflectType.Kind(); kind {
+	case reflect.Struct:
+		if isTime(reflectType) {
+			return DateTimeOffset, nil
+		}
+
+		typeDeclaration, err := utils.MapGetNonZero(c.TypeDeclarations, reflectType)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("map get non zero: %w", err), c.TypeDeclarations, reflectType)
+		}
+
+		interfaceDeclaration, err := utils.Convert[*type_declaration.InterfaceDeclaration](typeDeclaration)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("convert: %w", err), typeDeclaration)
+		}
+
+		typeReference := (&RecordDeclaration{InterfaceDeclaration: interfaceDeclaration, c: c}).TypeReference()
+
+		if genericTypeInfo := interfaceDeclaration.GenericTypeInfo; genericTypeInfo != nil {
+			for _, typeParameterName := range genericTypeInfo.TypeParameterNames {
+				argReflectType, ok := c.TypeArgument(reflectType, typeParameterName)
+				if !ok {
+					return nil, motmedelErrors.NewWithTrace(
+						typeGenerationErrors.ErrNoStructField,
+						reflectType, typeParameterName,
+					)
+				}
+
+				typeArgument, err := c.GetCSharpType(argReflectType)
+				if err != nil {
+					return nil, fmt.Errorf("get c# type: %w", err)
+				}
+				typeReference.TypeArguments = append(typeReference.TypeArguments, typeArgument)
+			}
+		}
+
+		return typeReference, nil
+	case reflect.Int8:
+		return SByte, nil
+	case reflect.Uint8:
+		return Byte, nil
+	case reflect.Int16:
+		return Short, nil
+	case reflect.Uint16:
+		return UShort, nil
+	case reflect.Int32:
+		return Int, nil
+	case reflect.Uint32:
+		return UInt, nil
+	case reflect.Int, reflect.Int64:
+		return Long, nil
+	case reflect.Uint, reflect.Uint64:
+		return ULong, nil
+	case reflect.Float32:
+		return Float, nil
+	case reflect.Float64:
+		return Double, nil
+	case reflect.String:
+		return String, nil
+	case reflect.Bool:
+		return Bool, nil
+	case reflect.Map:
+		// Only keys that both encoding/json and System.Text.Json represent as JSON object keys are supported.
+		keyType := reflectType.Key()
+		isTextMarshaler := typeGenerationContext.GetMarshalerKind(keyType) == typeGenerationContext.MarshalerKindText
+		if keyKind := keyType.Kind(); keyKind != reflect.String && !isTextMarshaler && !isInteger(keyKind) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, keyKind),
+				keyKind,
+			)
+		}
+
+		keyCSharpType, err := c.GetCSharpType(keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		valueType, err := c.GetCSharpType(reflectType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &DictionaryType{KeyType: keyCSharpType, ValueType: valueType}, nil
+	case reflect.Slice, reflect.Array:
+		// Both encoding/json and System.Text.Json encode byte slices as base64 strings.
+		if kind == reflect.Slice && reflectType.Elem().Kind() == reflect.Uint8 {
+			return ByteArray, nil
+		}
+
+		itemsType, err := c.GetCSharpType(reflectType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &ListType{ItemsType: itemsType}, nil
+	case reflect.Interface:
+		return JsonElement, nil
+	default:
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind)
+	}
+}
+
+// shapeProducer produces the C# types of the parts of a shape.
+type shapeProducer struct {
+	*Context
+}
+
+func (p shapeProducer) Type(reflectType reflect.Type) (Type, error) {
+	return p.GetCSharpType(reflectType)
+}
+
+func (p shapeProducer) KeyType(reflectType reflect.Type) (Type, error) {
+	return p.GetCSharpType(reflectType)
+}
+
+func (p shapeProducer) TypeParameter(name string) Type {
+	return &TypeParameter{Identifier: name}
+}
+
+func (p shapeProducer) ArrayType(itemsType Type) Type {
+	return &ListType{ItemsType: itemsType}
+}
+
+func (p shapeProducer) MapType(keyType Type, valueType Type) Type {
+	return &DictionaryType{KeyType: keyType, ValueType: valueType}
+}
+
+func (p shapeProducer) TypeArguments(t Type) []Type {
+	if typeReference, ok := t.(*TypeReference); ok {
+		return typeReference.TypeArguments
+	}
+	return nil
+}
+
+// getShapeType returns the C# type of a generic struct's field type, in which the parts using type parameters
+// are replaced by the parameters, e.g. `List<List<T>>` for a `[][]T` field.
+func (c *Context) getShapeType(typeShape *shape.Shape, reflectType reflect.Type) (Type, error) {
+	return shape.Resolve[Type](shapeProducer{c}, typeShape, reflectType)
+}
+
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Context) Render() (string, error) {
+	var stringBuilder strings.Builder
+
+	stringBuilder.WriteString("#nullable enable\n\n")
+	stringBuilder.WriteString("using System;\n")
+	stringBuilder.WriteString("using System.Collections.Generic;\n")
+	stringBuilder.WriteString("using System.Text.Json;\n")
+	stringBuilder.WriteString("using System.Text.Json.Serialization;\n")
+
+	if namespace := c.Namespace; namespace != "" {
+		stringBuilder.WriteString(fmt.Sprintf("\nnamespace %s;\n", namespace))
+	}
+
+	typeDeclarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return "", fmt.Errorf("ordered type declarations: %w", err)
+	}
+	typeDeclarations = c.RemoveMappedTypeDeclarations(typeDeclarations, hasTypeMapping)
+
+	for _, typeDeclaration := range typeDeclarations {
+		interfaceDeclaration, ok := typeDeclaration.(*type_declaration.InterfaceDeclaration)
+		// Instantiations of a generic type are rendered as one generic declaration.
+		if !ok || c.GetGenericDeclaration(interfaceDeclaration) != interfaceDeclaration {
+			continue
+		}
+
+		recordDeclaration := &RecordDeclaration{InterfaceDeclaration: interfaceDeclaration, c: c}
+		d, err := recordDeclaration.String()
+		if err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("record declaration string: %w", err), recordDeclaration)
+		}
+
+		stringBuilder.WriteString("\n")
+		stringBuilder.WriteString(d)
+		stringBuilder.WriteString("\n")
+	}
+
+	return stringBuilder.String(), nil
+}
+
+func renderTypeParams(params []string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("<%s>", strings.Join(params, ", "))
+}
+
+type RecordDeclaration struct {
+	*type_declaration.InterfaceDeclaration
+	c *Context
+}
+
+func (r *RecordDeclaration) String() (string, error) {
+	var parameterStrings []string
+
+	var typeParameters []string
+	genericTypeInfo := r.GenericTypeInfo
+	if genericTypeInfo != nil {
+		typeParameters = genericTypeInfo.TypeParameterNames
+	}
+
+	for _, property := range r.Properties {
+		if property == nil {
+			continue
+		}
+
+		field := property.Field
+		if field == nil {
+			return "", motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
+		}
+
+		jsonName := property.Identifier
+		optional := property.Optional
+
+		fieldTag := field.Tag
+
+		rawSchemaTag := fieldTag.Get("jsonschema")
+		schemaTag, err := jsonschemaTag.New(rawSchemaTag)
+		if err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("jsonschema tag new: %w", err), rawSchemaTag)
+		}
+		if schemaTag != nil {
+			if schemaTag.Skip {
+				continue
+			}
+
+			if name := schemaTag.Name; name != "" {
+				jsonName = name
+			}
+
+			optional = optional || schemaTag.Optional
+		} else {
+			jsonTag := motmedelJsonTag.New(fieldTag.Get("json"))
+			if jsonTag != nil {
+				if jsonTag.Skip {
+					continue
+				}
+				if name := jsonTag.Name; name != "" {
+					jsonName = name
+				}
+
+				optional = optional || jsonTag.OmitEmpty || jsonTag.OmitZero
+			}
+		}
+
+		csharpType, err := r.c.GetCSharpType(field.Type)
+		if err != nil {
+			return "", fmt.Errorf("get c# type: %w", err)
+		}
+
+		// Replace the field's type with the generic type parameter if the field uses the generic type parameter.
+
+		if genericTypeInfo != nil {
+			if fieldShape, ok := genericTypeInfo.FieldNameToShape[property.Identifier]; ok {
+				csharpType, err = r.c.getShapeType(fieldShape, field.Type)
+				if err != nil {
+					return "", fmt.Errorf("get shape type: %w", err)
+				}
+			}
+		}
+
+		attributes := []string{fmt.Sprintf("JsonPropertyName(%s)", strconv.Quote(jsonName))}
+		if optional || property.Nullable {
+			csharpType = &NullableType{Type: csharpType}
+		}
+		if optional {
+			attributes = append(attributes, "JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)")
+		}
+
+		typeString, err := csharpType.String()
+		if err != nil {
+			return "", fmt.Errorf("type string: %w", err)
+		}
+
+		parameterStrings = append(
+			parameterStrings,
+			fmt.Sprintf(
+				"\t[property: %s] %s %s",
+				strings.Join(attributes, ", "),
+				typeString,
+				r.c.PropertyNameConvention.Apply(property.Identifier),
+			),
+		)
+	}
+
+	if len(parameterStrings) == 0 {
+		return fmt.Sprintf("public record %s%s();", r.Identifier, renderTypeParams(typeParameters)), nil
+	}
+
+	return fmt.Sprintf(
+		"public record %s%s(\n%s\n);",
+		r.Identifier,
+		renderTypeParams(typeParameters),
+		strings.Join(parameterStrings, ",\n"),
+	), nil
+}
+
+func (r *RecordDeclaration) QualifiedName() string {
+	return r.Identifier
+}
+
+func (r *RecordDeclaration) TypeReference() *TypeReference {
+	return &TypeReference{TypeDeclaration: r}
+}
@@ -0,0 +1,23 @@
+package java
+
+import (
+	"fmt"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/java/types"
+	typeGenerationTypesContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+func Convert(packageName string, values ...any) (string, error) {
+	javaContext := types.Context{Context: typeGenerationTypesContext.New(), Package: packageName}
+	if err := javaContext.Add(values...); err != nil {
+		return "", fmt.Errorf("add: %w", err)
+	}
+
+	output, err := javaContext.Render()
+	if err != nil {
+		return "", motmedelErrors.New(fmt.Errorf("render: %w", err), javaContext)
+	}
+
+	return output, nil
+}
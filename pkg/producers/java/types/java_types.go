@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Type interface {
+	String() (string, error)
+}
+
+type TypeDeclaration interface {
+	TypeReference() *TypeReference
+	QualifiedName() string
+}
+
+type TypeReference struct {
+	TypeDeclaration TypeDeclaration
+	TypeArguments   []Type
+}
+
+func (t *TypeReference) String() (string, error) {
+	name := t.TypeDeclaration.QualifiedName()
+	if len(t.TypeArguments) == 0 {
+		return name, nil
+	}
+
+	args := make([]string, 0, len(t.TypeArguments))
+	for _, a := range t.TypeArguments {
+		typeStr, err := Box(a).String()
+		if err != nil {
+			return "", fmt.Errorf("type string: %w", err)
+		}
+		args = append(args, typeStr)
+	}
+
+	return fmt.Sprintf("%s<%s>", name, strings.Join(args, ", ")), nil
+}
+
+type TypeParameter struct {
+	Identifier string
+}
+
+func (p *TypeParameter) String() (string, error) { return p.Identifier, nil }
+
+// PrimitiveType is a Java primitive type, which cannot be null nor be used as a type argument.
+type PrimitiveType string
+
+const (
+	Boolean = PrimitiveType("boolean")
+	Byte    = PrimitiveType("byte")
+	Short   = PrimitiveType("short")
+	Int     = PrimitiveType("int")
+	Long    = PrimitiveType("long")
+	Float   = PrimitiveType("float")
+	Double  = PrimitiveType("double")
+)
+
+func (p PrimitiveType) String() (string, error) { return string(p), nil }
+
+var primitiveTypeToBoxedType = map[PrimitiveType]ClassType{
+	Boolean: "Boolean",
+	Byte:    "Byte",
+	Short:   "Short",
+	Int:     "Integer",
+	Long:    "Long",
+	Float:   "Float",
+	Double:  "Double",
+}
+
+type ClassType string
+
+const (
+	String         = ClassType("String")
+	BigInteger     = ClassType("BigInteger")
+	OffsetDateTime = ClassType("OffsetDateTime")
+	JsonNode       = ClassType("JsonNode")
+	ByteArray      = ClassType("byte[]")
+)
+
+func (c ClassType) String() (string, error) { return string(c), nil }
+
+// Box returns the boxed counterpart of primitive types, and the provided type as-is otherwise.
+func Box(t Type) Type {
+	if primitiveType, ok := t.(PrimitiveType); ok {
+		return primitiveTypeToBoxedType[primitiveType]
+	}
+	return t
+}
+
+type NullableType struct {
+	Type Type
+}
+
+func (n *NullableType) String() (string, error) {
+	// The annotation is a type-use annotation, which must be placed before the brackets of an array type.
+	if n.Type == ByteArray {
+		return "byte @Nullable []", nil
+	}
+
+	typeStr, err := Box(n.Type).String()
+	if err != nil {
+		return "", fmt.Errorf("type string: %w", err)
+	}
+
+	return "@Nullable " + typeStr, nil
+}
+
+type ListType struct {
+	ItemsType Type
+}
+
+func (l *ListType) String() (string, error) {
+	typeStr, err := Box(l.ItemsType).String()
+	if err != nil {
+		return "", fmt.Errorf("items type string: %w", err)
+	}
+
+	return fmt.Sprintf("List<%s>", typeStr), nil
+}
+
+type MapType struct {
+	KeyType   Type
+	ValueType Type
+}
+
+func (m *MapType) String() (string, error) {
+	keyTypeString, err := Box(m.KeyType).String()
+	if err != nil {
+		return "", fmt.Errorf("key type string: %w", err)
+	}
+
+	valueTypeString, err := Box(m.ValueType).String()
+	if err != nil {
+		return "", fmt.Errorf("value type string: %w", err)
+	}
+
+	return fmt.Sprintf("Map<%s, %s>", keyTypeString, valueTypeString), nil
+}
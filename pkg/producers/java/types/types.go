@@ -0,0 +1,422 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Motmedel/utils_go/pkg/errors/types/nil_error"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
+	"github.com/vphpersson/type_generation/pkg/types/shape"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/Motmedel/utils_go/pkg/utils"
+	jsonschemaTag "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+)
+
+const defaultClassName = "Types"
+
+var keywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true,
+	"catch": true, "char": true, "class": true, "const": true, "continue": true, "default": true,
+	"do": true, "double": true, "else": true, "enum": true, "extends": true, "final": true,
+	"finally": true, "float": true, "for": true, "goto": true, "if": true, "implements": true,
+	"import": true, "instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true, "return": true,
+	"short": true, "static": true, "strictfp": true, "super": true, "switch": true, "synchronized": true,
+	"this": true, "throw": true, "throws": true, "transient": true, "try": true, "void": true,
+	"volatile": true, "while": true, "true": true, "false": true, "null": true, "record": true,
+	"var": true, "yield": true,
+}
+
+// toComponentName converts an exported Go field name into a lower camel case Java record component name, treating
+// a leading acronym as one word (e.g. "ID" -> "id", "HTTPServer" -> "httpServer", "URLs" -> "urls").
+func toComponentName(fieldName string) string {
+	name := fieldName
+	if words := naming_convention.Words(fieldName); len(words) > 0 {
+		if rest, ok := strings.CutPrefix(fieldName, words[0]); ok {
+			name = strings.ToLower(words[0]) + rest
+		}
+	}
+
+	if keywords[name] {
+		name += "_"
+	}
+
+	return name
+}
+
+// componentName returns the record component name of the field, following the property naming convention of the
+// context if one is set.
+func (c *Context) componentName(fieldName string) string {
+	if c.PropertyNameConvention == naming_convention.Preserve {
+		return toComponentName(fieldName)
+	}
+	return c.PropertyNameConvention.Apply(fieldName)
+}
+
+func isTime(t reflect.Type) bool {
+	return t.Name() == "Time" && t.PkgPath() == "time"
+}
+
+// hasTypeMapping reports whether the type mapping has a Java representation, in which case the mapped type is not
+// rendered as a declaration.
+func hasTypeMapping(typeMapping *type_mapping.TypeMapping) bool {
+	return typeMapping.Java != ""
+}
+
+type Context struct {
+	*typeGenerationContext.Context
+	// Package is the package of the rendered file. No package is declared if empty.
+	Package string
+	// ClassName is the name of the class enclosing the rendered records. Defaults to "Types".
+	ClassName string
+}
+
+func (c *Context) GetJavaType(reflectType reflect.Type) (Type, error) {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.Java != "" {
+		return ClassType(typeMapping.Java), nil
+	}
+
+	if !isTime(reflectType) {
+		switch typeGenerationContext.GetMarshalerKind(reflectType) {
+		case typeGenerationContext.MarshalerKindText:
+			return String, nil
+		case typeGenerationContext.MarshalerKindJSON:
+			return JsonNode, nil
+		default:
+		}
+	}
+
+	switch kind := reflectType.Kind(); kind {
+	case reflect.Struct:
+		if isTime(reflectType) {
+			return OffsetDateTime, nil
+		}
+
+		typeDeclaration, err := utils.MapGetNonZero(c.TypeDeclarations, reflectType)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("map get non zero: %w", err), c.TypeDeclarations, reflectType)
+		}
+
+		interfaceDeclaration, err := utils.Convert[*type_declaration.InterfaceDeclaration](typeDeclaration)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("convert: %w", err), typeDeclaration)
+		}
+
+		typeReference := (&RecordDeclaration{InterfaceDeclaration: interfaceDeclaration, c: c}).TypeReference()
+
+		if genericTypeInfo := interfaceDeclaration.GenericTypeInfo; genericTypeInfo != nil {
+			for _, typeParameterName := range genericTypeInfo.TypeParameterNames {
+				argReflectType, ok := c.TypeArgument(reflectType, typeParameterName)
+				if !ok {
+					return nil, motmedelErrors.NewWithTrace(
+						typeGenerationErrors.ErrNoStructField,
+						reflectType, typeParameterName,
+					)
+				}
+
+				typeArgument, err := c.GetJavaType(argReflectType)
+				if err != nil {
+					return nil, fmt.Errorf("get java type: %w", err)
+				}
+				typeReference.TypeArguments = append(typeReference.TypeArguments, typeArgument)
+			}
+		}
+
+		return typeReference, nil
+	case reflect.Int8:
+		return Byte, nil
+	case reflect.Uint8, reflect.Int16:
+		return Short, nil
+	case reflect.Uint16, reflect.Int32:
+		return Int, nil
+	case reflect.Uint32, reflect.Int, reflect.Int64:
+		return Long, nil
+	case reflect.Uint, reflect.Uint64:
+		// Java has no unsigned 64-bit integer type.
+		return BigInteger, nil
+	case reflect.Float32:
+		return Float, nil
+	case reflect.Float64:
+		return Double, nil
+	case reflect.String:
+		return String, nil
+	case reflect.Bool:
+		return Boolean, nil
+	case reflect.Map:
+		keyType := reflectType.Key()
+		isTextMarshaler := typeGenerationContext.GetMarshalerKind(keyType) == typeGenerationContext.MarshalerKindText
+		if keyKind := keyType.Kind(); keyKind != reflect.String && !isTextMarshaler && !isInteger(keyKind) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, keyKind),
+				keyKind,
+			)
+		}
+
+		keyJavaType, err := c.GetJavaType(keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		valueType, err := c.GetJavaType(reflectType.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &MapType{KeyType: keyJavaType, ValueType: valueType}, nil
+	case reflect.Slice, reflect.Array:
+		// Both encoding/json and Jackson encode byte slices as base64 strings.
+		if kind == reflect.Slice && reflectType.Elem().Kind() == reflect.Uint8 {
+			return ByteArray, nil
+		}
+
+		itemsType, err := c.GetJavaType(reflectType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &ListType{ItemsType: itemsType}, nil
+	case reflect.Interface:
+		return JsonNode, nil
+	default:
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind)
+	}
+}
+
+// shapeProducer produces the Java types of the parts of a shape.
+type shapeProducer struct {
+	*Context
+}
+
+func (p shapeProducer) Type(reflectType reflect.Type) (Type, error) {
+	return p.GetJavaType(reflectType)
+}
+
+func (p shapeProducer) KeyType(reflectType reflect.Type) (Type, error) {
+	return p.GetJavaType(reflectType)
+}
+
+func (p shapeProducer) TypeParameter(name string) Type {
+	return &TypeParameter{Identifier: name}
+}
+
+func (p shapeProducer) ArrayType(itemsType Type) Type {
+	return &ListType{ItemsType: itemsType}
+}
+
+func (p shapeProducer) MapType(keyType Type, valueType Type) Type {
+	return &MapType{KeyType: keyType, ValueType: valueType}
+}
+
+func (p shapeProducer) TypeArguments(t Type) []Type {
+	if typeReference, ok := t.(*TypeReference); ok {
+		return typeReference.TypeArguments
+	}
+	return nil
+}
+
+// getShapeType returns the Java type of a generic struct's field type, in which the parts using type parameters
+// are replaced by the parameters, e.g. `List<List<T>>` for a `[][]T` field.
+func (c *Context) getShapeType(typeShape *shape.Shape, reflectType reflect.Type) (Type, error) {
+	return shape.Resolve[Type](shapeProducer{c}, typeShape, reflectType)
+}
+
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Context) Render() (string, error) {
+	className := c.ClassName
+	if className == "" {
+		className = defaultClassName
+	}
+
+	var stringBuilder strings.Builder
+
+	if pkg := c.Package; pkg != "" {
+		stringBuilder.WriteString(fmt.Sprintf("package %s;\n\n", pkg))
+	}
+
+	stringBuilder.WriteString("import com.fasterxml.jackson.annotation.JsonInclude;\n")
+	stringBuilder.WriteString("import com.fasterxml.jackson.annotation.JsonProperty;\n")
+	stringBuilder.WriteString("import com.fasterxml.jackson.databind.JsonNode;\n")
+	stringBuilder.WriteString("import java.math.BigInteger;\n")
+	stringBuilder.WriteString("import java.time.OffsetDateTime;\n")
+	stringBuilder.WriteString("import java.util.List;\n")
+	stringBuilder.WriteString("import java.util.Map;\n")
+	stringBuilder.WriteString("import org.jspecify.annotations.Nullable;\n\n")
+
+	// Java allows only one public top-level type per file, so the records are nested in a holder class.
+	stringBuilder.WriteString(fmt.Sprintf("public final class %s {\n\tprivate %s() {}\n", className, className))
+
+	typeDeclarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return "", fmt.Errorf("ordered type declarations: %w", err)
+	}
+	typeDeclarations = c.RemoveMappedTypeDeclarations(typeDeclarations, hasTypeMapping)
+
+	for _, typeDeclaration := range typeDeclarations {
+		interfaceDeclaration, ok := typeDeclaration.(*type_declaration.InterfaceDeclaration)
+		// Instantiations of a generic type are rendered as one generic declaration.
+		if !ok || c.GetGenericDeclaration(interfaceDeclaration) != interfaceDeclaration {
+			continue
+		}
+
+		recordDeclaration := &RecordDeclaration{InterfaceDeclaration: interfaceDeclaration, c: c}
+		d, err := recordDeclaration.String()
+		if err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("record declaration string: %w", err), recordDeclaration)
+		}
+
+		stringBuilder.WriteString("\n")
+		for _, line := range strings.Split(d, "\n") {
+			stringBuilder.WriteString("\t")
+			stringBuilder.WriteString(line)
+			stringBuilder.WriteString("\n")
+		}
+	}
+
+	stringBuilder.WriteString("}\n")
+
+	return stringBuilder.String(), nil
+}
+
+func renderTypeParams(params []string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("<%s>", strings.Join(params, ", "))
+}
+
+type RecordDeclaration struct {
+	*type_declaration.InterfaceDeclaration
+	c *Context
+}
+
+func (r *RecordDeclaration) String() (string, error) {
+	var componentStrings []string
+
+	var typeParameters []string
+	genericTypeInfo := r.GenericTypeInfo
+	if genericTypeInfo != nil {
+		typeParameters = genericTypeInfo.TypeParameterNames
+	}
+
+	for _, property := range r.Properties {
+		if property == nil {
+			continue
+		}
+
+		field := property.Field
+		if field == nil {
+			return "", motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
+		}
+
+		jsonName := property.Identifier
+		optional := property.Optional
+
+		fieldTag := field.Tag
+
+		rawSchemaTag := fieldTag.Get("jsonschema")
+		schemaTag, err := jsonschemaTag.New(rawSchemaTag)
+		if err != nil {
+			return "", motmedelErrors.New(fmt.Errorf("jsonschema tag new: %w", err), rawSchemaTag)
+		}
+		if schemaTag != nil {
+			if schemaTag.Skip {
+				continue
+			}
+
+			if name := schemaTag.Name; name != "" {
+				jsonName = name
+			}
+
+			optional = optional || schemaTag.Optional
+		} else {
+			jsonTag := motmedelJsonTag.New(fieldTag.Get("json"))
+			if jsonTag != nil {
+				if jsonTag.Skip {
+					continue
+				}
+				if name := jsonTag.Name; name != "" {
+					jsonName = name
+				}
+
+				optional = optional || jsonTag.OmitEmpty || jsonTag.OmitZero
+			}
+		}
+
+		javaType, err := r.c.GetJavaType(field.Type)
+		if err != nil {
+			return "", fmt.Errorf("get java type: %w", err)
+		}
+
+		// Replace the field's type with the generic type parameter if the field uses the generic type parameter.
+
+		if genericTypeInfo != nil {
+			if fieldShape, ok := genericTypeInfo.FieldNameToShape[property.Identifier]; ok {
+				javaType, err = r.c.getShapeType(fieldShape, field.Type)
+				if err != nil {
+					return "", fmt.Errorf("get shape type: %w", err)
+				}
+			}
+		}
+
+		annotations := []string{fmt.Sprintf("@JsonProperty(%s)", strconv.Quote(jsonName))}
+		if optional || property.Nullable {
+			javaType = &NullableType{Type: javaType}
+		}
+		if optional {
+			annotations = append(annotations, "@JsonInclude(JsonInclude.Include.NON_NULL)")
+		}
+
+		typeString, err := javaType.String()
+		if err != nil {
+			return "", fmt.Errorf("type string: %w", err)
+		}
+
+		componentStrings = append(
+			componentStrings,
+			fmt.Sprintf(
+				"\t%s %s %s",
+				strings.Join(annotations, " "),
+				typeString,
+				r.c.componentName(property.Identifier),
+			),
+		)
+	}
+
+	if len(componentStrings) == 0 {
+		return fmt.Sprintf("public record %s%s() {}", r.Identifier, renderTypeParams(typeParameters)), nil
+	}
+
+	return fmt.Sprintf(
+		"public record %s%s(\n%s\n) {}",
+		r.Identifier,
+		renderTypeParams(typeParameters),
+		strings.Join(componentStrings, ",\n"),
+	), nil
+}
+
+func (r *RecordDeclaration) QualifiedName() string {
+	return r.Identifier
+}
+
+func (r *RecordDeclaration) TypeReference() *TypeReference {
+	return &TypeReference{TypeDeclaration: r}
+}
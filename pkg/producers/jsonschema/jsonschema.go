@@ -9,8 +9,15 @@ import (
     typeGenerationTypesContext "github.com/vphpersson/type_generation/pkg/types/context"
 )
 
+// Convert renders the schema of the root type with the strict options, describing strings and arrays as non-empty and
+// objects as not having additional properties.
 func Convert(root reflect.Type) (string, error) {
-    jsonschemaContext := types.Context{Context: typeGenerationTypesContext.New()}
+    return ConvertWithOptions(root, types.StrictOptions())
+}
+
+// ConvertWithOptions renders the schema of the root type with the provided options.
+func ConvertWithOptions(root reflect.Type, options types.Options) (string, error) {
+    jsonschemaContext := types.Context{Context: typeGenerationTypesContext.New(), Options: options}
     if err := jsonschemaContext.Add(root); err != nil {
         return "", fmt.Errorf("add: %w", err)
     }
@@ -22,3 +29,39 @@ func Convert(root reflect.Type) (string, error) {
 
     return output, nil
 }
+
+// ConvertBundle renders a schema document with the definitions of the types of the values, and of the types that they
+// reference, with the strict options.
+func ConvertBundle(values ...any) (string, error) {
+    jsonschemaContext := types.Context{Context: typeGenerationTypesContext.New(), Options: types.StrictOptions()}
+    if err := jsonschemaContext.Add(values...); err != nil {
+        return "", fmt.Errorf("add: %w", err)
+    }
+
+    output, err := jsonschemaContext.RenderBundle()
+    if err != nil {
+        return "", motmedelErrors.New(fmt.Errorf("render bundle: %w", err), jsonschemaContext)
+    }
+
+    return output, nil
+}
+
+// ConvertFiles renders one schema document per type, with the strict options, and with `$id`s derived from the base
+// URI.
+func ConvertFiles(baseURI string, values ...any) (map[string]string, error) {
+    jsonschemaContext := types.Context{
+        Context: typeGenerationTypesContext.New(),
+        Options: types.StrictOptions(),
+        BaseURI: baseURI,
+    }
+    if err := jsonschemaContext.Add(values...); err != nil {
+        return nil, fmt.Errorf("add: %w", err)
+    }
+
+    files, err := jsonschemaContext.RenderFiles()
+    if err != nil {
+        return nil, motmedelErrors.New(fmt.Errorf("render files: %w", err), jsonschemaContext)
+    }
+
+    return files, nil
+}
@@ -0,0 +1,306 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/Motmedel/utils_go/pkg/utils"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/schema"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// Draft is a version of the JSON Schema specification.
+type Draft int
+
+const (
+	// Draft202012 is JSON Schema 2020-12, in which definitions are placed under `$defs`.
+	Draft202012 Draft = iota
+	// Draft07 is JSON Schema draft-07, for older validators, in which definitions are placed under `definitions` and
+	// keywords adjacent to `$ref` are ignored.
+	Draft07
+)
+
+// fileExtension is the extension of the files of RenderFiles.
+const fileExtension = ".json"
+
+// schemaURI returns the meta-schema URI of the context's draft.
+func (c *Context) schemaURI() (string, error) {
+	switch c.Draft {
+	case Draft202012:
+		return "https://json-schema.org/draft/2020-12/schema", nil
+	case Draft07:
+		return "http://json-schema.org/draft-07/schema#", nil
+	default:
+		return "", motmedelErrors.NewWithTrace(typeGenerationErrors.ErrInvalidDraft, c.Draft)
+	}
+}
+
+// definitionsKeyword returns the keyword under which the context's draft places definitions.
+func (c *Context) definitionsKeyword() string {
+	if c.Draft == Draft07 {
+		return "definitions"
+	}
+	return "$defs"
+}
+
+// reference returns the `$ref` value that refers to the definition with the provided name: a fragment referring to
+// the definitions of the same document, or, when rendering files, the relative URI of the definition's file.
+func (c *Context) reference(name string) string {
+	if c.referenceFiles {
+		return name + fileExtension
+	}
+	return "#/" + c.definitionsKeyword() + "/" + name
+}
+
+// isolateReference moves the `$ref` of a schema with other keywords into `allOf` for draft-07, which ignores keywords
+// adjacent to `$ref`.
+func (c *Context) isolateReference(s *schema.Schema) *schema.Schema {
+	if s.Ref == "" || c.Draft != Draft07 {
+		return s
+	}
+
+	others := *s
+	others.Ref = ""
+	if others.IsEmpty() {
+		return s
+	}
+
+	others.AllOf = append([]*schema.Schema{{Ref: s.Ref}}, others.AllOf...)
+
+	return &others
+}
+
+// definition is the schema of a declaration, named as it is referenced.
+type definition struct {
+	name   string
+	schema *schema.Schema
+}
+
+// buildDefinitions builds the schemas of the declarations of the context, in the context's ordering.
+func (c *Context) buildDefinitions() ([]definition, error) {
+	typeDeclarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return nil, fmt.Errorf("ordered type declarations: %w", err)
+	}
+	typeDeclarations = c.RemoveMappedTypeDeclarations(typeDeclarations, hasTypeMapping)
+
+	var definitions []definition
+	for _, typeDeclaration := range typeDeclarations {
+		switch declaration := typeDeclaration.(type) {
+		case *type_declaration.InterfaceDeclaration:
+			if declaration == nil {
+				continue
+			}
+
+			interfaceSchema, err := c.buildInterfaceSchema(declaration)
+			if err != nil {
+				return nil, motmedelErrors.New(fmt.Errorf("build interface schema: %w", err), declaration)
+			}
+
+			// Each instantiation of a generic type is a separate definition, described with its concrete field types.
+			definitions = append(
+				definitions,
+				definition{name: c.InstantiationName(declaration), schema: interfaceSchema},
+			)
+		case *type_declaration.UnionDeclaration:
+			if declaration == nil {
+				continue
+			}
+
+			unionSchema, err := c.buildUnionSchema(declaration)
+			if err != nil {
+				return nil, motmedelErrors.New(fmt.Errorf("build union schema: %w", err), declaration)
+			}
+
+			definitions = append(definitions, definition{name: declaration.Identifier, schema: unionSchema})
+		}
+	}
+
+	return definitions, nil
+}
+
+// buildDocument builds a document with the definitions of the declarations of the context, in the context's
+// ordering, under the keyword of the context's draft.
+func (c *Context) buildDocument() (*schema.Schema, error) {
+	schemaURI, err := c.schemaURI()
+	if err != nil {
+		return nil, fmt.Errorf("schema uri: %w", err)
+	}
+
+	definitions, err := c.buildDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("build definitions: %w", err)
+	}
+
+	definitionsMap := schema.NewMap()
+	for _, definition := range definitions {
+		definitionsMap.Set(definition.name, definition.schema)
+	}
+
+	document := &schema.Schema{Schema: schemaURI}
+	if c.Draft == Draft07 {
+		document.Definitions = definitionsMap
+	} else {
+		document.Defs = definitionsMap
+	}
+
+	return document, nil
+}
+
+// MarshalSchema encodes a schema, e.g. one built with BuildRoot and then modified, indented according to the context.
+// Properties and definitions are encoded in order, which makes the output deterministic.
+func (c *Context) MarshalSchema(s *schema.Schema) (string, error) {
+	data, err := schema.MarshalIndent(s, c.Indent)
+	if err != nil {
+		return "", fmt.Errorf("marshal indent (schema): %w", err)
+	}
+
+	return string(data), nil
+}
+
+// BuildRoot builds a single JSON Schema document with the provided root type as the top-level schema and all
+// discovered declarations included as definitions. References refer to the definitions of the document.
+func (c *Context) BuildRoot(root reflect.Type) (*schema.Schema, error) {
+	root = motmedelReflect.RemoveIndirection(root)
+
+	rootKind := root.Kind()
+	if rootKind != reflect.Struct {
+		return nil, motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, rootKind)
+	}
+
+	rootTypeDeclaration, ok := c.TypeDeclarations[root]
+	if !ok {
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w (root type)", motmedelErrors.ErrNotInMap),
+			root,
+		)
+	}
+
+	rootInterfaceDeclaration, err := utils.ConvertToNonZero[*type_declaration.InterfaceDeclaration](rootTypeDeclaration)
+	if err != nil {
+		return nil, motmedelErrors.New(
+			fmt.Errorf("convert to non zero (root type declaration): %w", err),
+			rootTypeDeclaration,
+		)
+	}
+
+	document, err := c.buildDocument()
+	if err != nil {
+		return nil, fmt.Errorf("build document: %w", err)
+	}
+
+	rootInterfaceDeclarationIdentifier := c.InstantiationName(rootInterfaceDeclaration)
+
+	// Reference the root schema via the definitions to avoid duplicating the object at the top level.
+	document.Title = rootInterfaceDeclarationIdentifier
+	document.Ref = c.reference(rootInterfaceDeclarationIdentifier)
+
+	return c.isolateReference(document), nil
+}
+
+// RenderRoot renders the document of BuildRoot.
+func (c *Context) RenderRoot(root reflect.Type) (string, error) {
+	document, err := c.BuildRoot(root)
+	if err != nil {
+		return "", fmt.Errorf("build root: %w", err)
+	}
+
+	return c.MarshalSchema(document)
+}
+
+// BuildBundle builds a single JSON Schema document that contains all discovered declarations as definitions, for
+// documents with several roots. The document itself accepts any value; its definitions are referred to with
+// fragments, e.g. "#/$defs/Name".
+func (c *Context) BuildBundle() (*schema.Schema, error) {
+	document, err := c.buildDocument()
+	if err != nil {
+		return nil, fmt.Errorf("build document: %w", err)
+	}
+
+	return document, nil
+}
+
+// RenderBundle renders the document of BuildBundle.
+func (c *Context) RenderBundle() (string, error) {
+	document, err := c.BuildBundle()
+	if err != nil {
+		return "", fmt.Errorf("build bundle: %w", err)
+	}
+
+	return c.MarshalSchema(document)
+}
+
+// BuildFiles builds one JSON Schema document per discovered declaration, with references between documents given
+// as relative URIs of their files. The `$id` of each document is derived from the context's base URI, if any. The
+// returned map maps the file names, e.g. "Name.json", to the documents. File names that differ only in case are a
+// name collision.
+func (c *Context) BuildFiles() (map[string]*schema.Schema, error) {
+	schemaURI, err := c.schemaURI()
+	if err != nil {
+		return nil, fmt.Errorf("schema uri: %w", err)
+	}
+
+	c.referenceFiles = true
+	defer func() { c.referenceFiles = false }()
+
+	definitions, err := c.buildDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("build definitions: %w", err)
+	}
+
+	files := make(map[string]*schema.Schema, len(definitions))
+	// File names are compared case-insensitively, as are the file names of some file systems, on which documents
+	// such as "APIKey.json" and "ApiKey.json" would overwrite each other.
+	foldedFileNames := make(map[string]string, len(definitions))
+	for _, definition := range definitions {
+		fileName := definition.name + fileExtension
+
+		foldedFileName := strings.ToLower(fileName)
+		if otherFileName, ok := foldedFileNames[foldedFileName]; ok {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf(
+					"%w: the files %s and %s differ only in case",
+					typeGenerationErrors.ErrNameCollision,
+					otherFileName,
+					fileName,
+				),
+				definition.name,
+			)
+		}
+		foldedFileNames[foldedFileName] = fileName
+
+		document := definition.schema
+		document.Schema = schemaURI
+		document.Title = definition.name
+		if c.BaseURI != "" {
+			document.ID = strings.TrimSuffix(c.BaseURI, "/") + "/" + fileName
+		}
+
+		files[fileName] = document
+	}
+
+	return files, nil
+}
+
+// RenderFiles renders the documents of BuildFiles.
+func (c *Context) RenderFiles() (map[string]string, error) {
+	documents, err := c.BuildFiles()
+	if err != nil {
+		return nil, fmt.Errorf("build files: %w", err)
+	}
+
+	files := make(map[string]string, len(documents))
+	for fileName, document := range documents {
+		data, err := c.MarshalSchema(document)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("marshal schema: %w", err), fileName)
+		}
+		files[fileName] = data
+	}
+
+	return files, nil
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+)
+
+// fieldSchemaType returns the type of the schema that describes the field's value, to which the constraints of the
+// field's tag apply, or an empty string if the schema has no single type, as for references and values of any type.
+func (c *Context) fieldSchemaType(field reflect.StructField) string {
+	if typeGenerationContext.IsQuoted(field) {
+		return singleType(quotedSchema(motmedelReflect.RemoveIndirection(field.Type)))
+	}
+
+	// Types that are described by references, which are only resolved for declared types, have no single type.
+	fieldSchema, err := c.GetJSONSchemaType(field.Type)
+	if err != nil {
+		return ""
+	}
+
+	return singleType(fieldSchema)
+}
+
+func inapplicable(option string, fieldType reflect.Type) error {
+	return motmedelErrors.NewWithTrace(
+		fmt.Errorf("%w: %s does not apply to %s", typeGenerationErrors.ErrInapplicableTagOption, option, fieldType),
+	)
+}
+
+// LintTag reports the problems of the `jsonschema` tag of a field: unknown options, malformed options, options that
+// conflict with each other, constraints that do not apply to the schema of the field, and thus are not part of it,
+// and values that are not values of the schema's type.
+func (c *Context) LintTag(field reflect.StructField) error {
+	jsonschemaTag, err := tag.New(field.Tag.Get("jsonschema"))
+	if err != nil {
+		return fmt.Errorf("%w: %w", typeGenerationErrors.ErrInvalidTag, err)
+	}
+	if jsonschemaTag == nil {
+		return nil
+	}
+
+	errs := []error{jsonschemaTag.Validate()}
+
+	schemaType := c.fieldSchemaType(field)
+	isNumber := schemaType == "integer" || schemaType == "number"
+
+	for _, check := range []struct {
+		option     string
+		present    bool
+		applicable bool
+	}{
+		{"minlength", jsonschemaTag.MinLength != nil, schemaType == "string"},
+		{"maxlength", jsonschemaTag.MaxLength != nil, schemaType == "string"},
+		{"pattern", jsonschemaTag.Pattern != "", schemaType == "string"},
+		{"minimum", jsonschemaTag.Minimum != nil, isNumber},
+		{"maximum", jsonschemaTag.Maximum != nil, isNumber},
+		{"exclusiveminimum", jsonschemaTag.ExclusiveMinimum != nil, isNumber},
+		{"exclusivemaximum", jsonschemaTag.ExclusiveMaximum != nil, isNumber},
+		{"multipleof", jsonschemaTag.MultipleOf != nil, isNumber},
+		{"minitems", jsonschemaTag.MinItems != nil, schemaType == "array"},
+		{"maxitems", jsonschemaTag.MaxItems != nil, schemaType == "array"},
+		{"uniqueitems", jsonschemaTag.UniqueItems, schemaType == "array"},
+		{"minproperties", jsonschemaTag.MinProperties != nil, schemaType == "object"},
+		{"maxproperties", jsonschemaTag.MaxProperties != nil, schemaType == "object"},
+		// The option applies to the struct that declares the field, and is only used in tags of `_` fields.
+		{"additionalproperties", jsonschemaTag.AdditionalProperties != nil, field.Name == "_"},
+	} {
+		if check.present && !check.applicable {
+			errs = append(errs, inapplicable(check.option, field.Type))
+		}
+	}
+
+	values := slices.Concat(jsonschemaTag.Enum, jsonschemaTag.Examples)
+	for _, value := range []*string{jsonschemaTag.Const, jsonschemaTag.Default} {
+		if value != nil {
+			values = append(values, *value)
+		}
+	}
+	for _, value := range values {
+		if _, err := tag.ParseValue(value, schemaType); err != nil {
+			errs = append(errs, fmt.Errorf("%w: parse value: %w", typeGenerationErrors.ErrInvalidTag, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
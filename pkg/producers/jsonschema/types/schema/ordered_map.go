@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+)
+
+// Map is a JSON object of schemas, e.g. `properties` or `$defs`, whose members are encoded in the order in which they
+// were first set, or in which they were decoded.
+type Map struct {
+	keys    []string
+	schemas map[string]*Schema
+}
+
+func NewMap() *Map {
+	return &Map{schemas: map[string]*Schema{}}
+}
+
+// Set sets the schema of the key, which keeps its position if it is already set.
+func (m *Map) Set(key string, s *Schema) {
+	if m.schemas == nil {
+		m.schemas = map[string]*Schema{}
+	}
+	if _, ok := m.schemas[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.schemas[key] = s
+}
+
+func (m *Map) Get(key string) (*Schema, bool) {
+	if m == nil {
+		return nil, false
+	}
+	s, ok := m.schemas[key]
+	return s, ok
+}
+
+func (m *Map) Delete(key string) {
+	if m == nil {
+		return
+	}
+	if _, ok := m.schemas[key]; !ok {
+		return
+	}
+	delete(m.schemas, key)
+	m.keys = slices.DeleteFunc(m.keys, func(k string) bool { return k == key })
+}
+
+// Keys returns the keys in order.
+func (m *Map) Keys() []string {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.keys)
+}
+
+func (m *Map) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.keys)
+}
+
+func (m *Map) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		if err := writeMember(&buffer, key, m.schemas[key]); err != nil {
+			return nil, fmt.Errorf("write member: %w", err)
+		}
+	}
+
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func (m *Map) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("json decoder token: %w", err), data)
+	}
+	if delimiter, ok := token.(json.Delim); !ok || delimiter != '{' {
+		return motmedelErrors.NewWithTrace(ErrNotObject, data)
+	}
+
+	*m = Map{schemas: map[string]*Schema{}}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("json decoder token: %w", err), data)
+		}
+		// The tokens at the positions of keys are strings.
+		key, _ := token.(string)
+
+		var s Schema
+		if err := decoder.Decode(&s); err != nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("json decoder decode: %w", err), key)
+		}
+		m.Set(key, &s)
+	}
+
+	return nil
+}
+
+func writeMember(buffer *bytes.Buffer, key string, value any) error {
+	keyData, err := marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshal (key): %w", err)
+	}
+	buffer.Write(keyData)
+	buffer.WriteByte(':')
+
+	valueData, err := marshal(value)
+	if err != nil {
+		return motmedelErrors.New(fmt.Errorf("marshal (value): %w", err), key)
+	}
+	buffer.Write(valueData)
+
+	return nil
+}
+
+// MarshalIndent encodes the value as JSON without escaping HTML characters, which are common in patterns, and
+// indents it with the provided string per level, if any.
+func MarshalIndent(value any, indent string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if indent != "" {
+		encoder.SetIndent("", indent)
+	}
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json encoder encode: %w", err), value)
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+func marshal(value any) ([]byte, error) {
+	return MarshalIndent(value, "")
+}
@@ -0,0 +1,275 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+)
+
+var ErrNotObject = errors.New("not an object")
+
+// Type is the `type` keyword, encoded as a string if it has one type and as an array otherwise.
+type Type []string
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return marshal(t[0])
+	}
+	return marshal([]string(t))
+}
+
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Type{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (type): %w", err), data)
+	}
+	*t = multiple
+
+	return nil
+}
+
+// Schema is a JSON Schema of the 2020-12 vocabulary, with `definitions` of draft-07. A schema with Bool set is the
+// boolean schema `true` or `false`. Keywords without a field are kept in Extensions. The array form of `items` of
+// draft-07 is decoded as PrefixItems, with `additionalItems` as Items.
+//
+// The values of Const and Default are omitted if nil, and thus cannot be `null`. Required is omitted only if nil, so
+// that an empty list can be encoded as `[]`.
+type Schema struct {
+	Bool *bool `json:"-"`
+
+	Schema  string `json:"$schema,omitempty"`
+	ID      string `json:"$id,omitempty"`
+	Anchor  string `json:"$anchor,omitempty"`
+	Comment string `json:"$comment,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Examples    []any  `json:"examples,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	ReadOnly    bool   `json:"readOnly,omitempty"`
+	WriteOnly   bool   `json:"writeOnly,omitempty"`
+
+	Type  Type  `json:"type,omitempty"`
+	Enum  []any `json:"enum,omitempty"`
+	Const any   `json:"const,omitempty"`
+
+	Format           string `json:"format,omitempty"`
+	MinLength        *int   `json:"minLength,omitempty"`
+	MaxLength        *int   `json:"maxLength,omitempty"`
+	Pattern          string `json:"pattern,omitempty"`
+	ContentEncoding  string `json:"contentEncoding,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+
+	PrefixItems      []*Schema `json:"prefixItems,omitempty"`
+	Items            *Schema   `json:"items,omitempty"`
+	Contains         *Schema   `json:"contains,omitempty"`
+	MinContains      *int      `json:"minContains,omitempty"`
+	MaxContains      *int      `json:"maxContains,omitempty"`
+	MinItems         *int      `json:"minItems,omitempty"`
+	MaxItems         *int      `json:"maxItems,omitempty"`
+	UniqueItems      bool      `json:"uniqueItems,omitempty"`
+	UnevaluatedItems *Schema   `json:"unevaluatedItems,omitempty"`
+
+	Properties            *Map                `json:"properties,omitempty"`
+	PatternProperties     *Map                `json:"patternProperties,omitempty"`
+	AdditionalProperties  *Schema             `json:"additionalProperties,omitempty"`
+	PropertyNames         *Schema             `json:"propertyNames,omitempty"`
+	Required              []string            `json:"required,omitzero"`
+	DependentRequired     map[string][]string `json:"dependentRequired,omitempty"`
+	DependentSchemas      *Map                `json:"dependentSchemas,omitempty"`
+	MinProperties         *int                `json:"minProperties,omitempty"`
+	MaxProperties         *int                `json:"maxProperties,omitempty"`
+	UnevaluatedProperties *Schema             `json:"unevaluatedProperties,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+	If    *Schema   `json:"if,omitempty"`
+	Then  *Schema   `json:"then,omitempty"`
+	Else  *Schema   `json:"else,omitempty"`
+
+	Defs        *Map `json:"$defs,omitempty"`
+	Definitions *Map `json:"definitions,omitempty"`
+
+	// Extensions are keywords without a field, e.g. annotations of other vocabularies; they are encoded after the
+	// other keywords, sorted by key.
+	Extensions map[string]any `json:"-"`
+}
+
+// Boolean returns the boolean schema, which accepts any value if true and no value if false.
+func Boolean(value bool) *Schema {
+	return &Schema{Bool: &value}
+}
+
+// IsEmpty reports whether the schema has no keywords, i.e. accepts any value.
+func (s *Schema) IsEmpty() bool {
+	return s != nil && s.Bool == nil && reflect.ValueOf(*s).IsZero()
+}
+
+// Clone returns a deep copy of the schema.
+func (s *Schema) Clone() (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json marshal: %w", err), s)
+	}
+
+	var clone Schema
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal: %w", err), data)
+	}
+
+	return &clone, nil
+}
+
+// FromMap converts a schema represented as a map, e.g. that of a type mapping, into a Schema.
+func FromMap(schemaMap map[string]any) (*Schema, error) {
+	data, err := json.Marshal(schemaMap)
+	if err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json marshal (schema map): %w", err), schemaMap)
+	}
+
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal: %w", err), data)
+	}
+
+	return &s, nil
+}
+
+// schemaFields is Schema without its methods, for encoding its fields with the default encoding.
+type schemaFields Schema
+
+// keywords returns the set of keywords that have a field.
+var keywords = sync.OnceValue(func() map[string]struct{} {
+	keywordSet := map[string]struct{}{}
+
+	schemaType := reflect.TypeFor[schemaFields]()
+	for i := range schemaType.NumField() {
+		name, _, _ := strings.Cut(schemaType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keywordSet[name] = struct{}{}
+		}
+	}
+
+	return keywordSet
+})
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.Bool != nil {
+		return marshal(*s.Bool)
+	}
+
+	data, err := marshal((*schemaFields)(s))
+	if err != nil {
+		return nil, fmt.Errorf("marshal (schema fields): %w", err)
+	}
+	if len(s.Extensions) == 0 {
+		return data, nil
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(bytes.TrimSuffix(data, []byte("}")))
+	hasMembers := len(data) > len("{}")
+	for _, key := range slices.Sorted(maps.Keys(s.Extensions)) {
+		// Keywords with a field are encoded from the field.
+		if _, ok := keywords()[key]; ok {
+			continue
+		}
+
+		if hasMembers {
+			buffer.WriteByte(',')
+		}
+		hasMembers = true
+		if err := writeMember(&buffer, key, s.Extensions[key]); err != nil {
+			return nil, fmt.Errorf("write member: %w", err)
+		}
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(data, &boolean); err == nil {
+		*s = Schema{Bool: &boolean}
+		return nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (members): %w", err), data)
+	}
+
+	// The array form of `items` of draft-07 corresponds to `prefixItems`, and `additionalItems` then to `items`.
+	var prefixItems []*Schema
+	if items := bytes.TrimSpace(members["items"]); bytes.HasPrefix(items, []byte("[")) {
+		if err := json.Unmarshal(items, &prefixItems); err != nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (items array): %w", err), items)
+		}
+		delete(members, "items")
+		if additionalItems, ok := members["additionalItems"]; ok {
+			members["items"] = additionalItems
+			delete(members, "additionalItems")
+		}
+
+		var err error
+		data, err = json.Marshal(members)
+		if err != nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("json marshal (members): %w", err), members)
+		}
+	}
+
+	var fields schemaFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (schema fields): %w", err), data)
+	}
+	if prefixItems != nil {
+		fields.PrefixItems = prefixItems
+	}
+	for key, value := range members {
+		if _, ok := keywords()[key]; ok {
+			continue
+		}
+
+		var extension any
+		if err := json.Unmarshal(value, &extension); err != nil {
+			return motmedelErrors.NewWithTrace(fmt.Errorf("json unmarshal (extension): %w", err), value)
+		}
+		if fields.Extensions == nil {
+			fields.Extensions = map[string]any{}
+		}
+		fields.Extensions[key] = extension
+	}
+
+	*s = Schema(fields)
+
+	return nil
+}
@@ -1,27 +1,175 @@
 package tag
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+)
+
+var (
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+	ErrNotList           = errors.New("not a list")
 )
 
 type Tag struct {
-	Name         string
-	Skip         bool
-	Optional     bool
-	MinLength    *int
-	MaxLength    *int
-	Minimum      *float64
-	Maximum      *float64
-	MaxItems     *int
-	MinItems     *int
-	Format       string
+	Name             string
+	Skip             bool
+	Optional         bool
+	MinLength        *int
+	MaxLength        *int
+	Pattern          string
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	MultipleOf       *float64
+	MaxItems         *int
+	MinItems         *int
+	UniqueItems      bool
+	MinProperties    *int
+	MaxProperties    *int
+	// AdditionalProperties applies to the struct that declares the field, and is only used in tags of `_` fields.
+	AdditionalProperties *bool
+	Format               string
+	// Enum, Const, Default and Examples are values as written in the tag; see ParseValue.
+	Enum         []string
+	Const        *string
+	Default      *string
+	Examples     []string
+	Deprecated   bool
+	ReadOnly     bool
+	WriteOnly    bool
+	Title        string
+	Description  string
 	OtherOptions []string
 }
 
+// split splits the string at the separators that are neither quoted nor within brackets. A value is quoted with
+// single quotes, within which a single quote is escaped by another single quote. A single quote only starts a quote at
+// the start of a value, i.e. after a separator, a colon or an opening bracket, so that values such as `the user's name`
+// need no quoting.
+func split(s string, separator byte) ([]string, error) {
+	var parts []string
+	start := 0
+	depth := 0
+	inQuote := false
+	atValueStart := true
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if inQuote {
+			if c == '\'' {
+				if i+1 < len(s) && s[i+1] == '\'' {
+					i++
+					continue
+				}
+				inQuote = false
+			}
+			continue
+		}
+
+		if c == '\'' && atValueStart {
+			inQuote = true
+			atValueStart = false
+			continue
+		}
+
+		if c != ' ' && c != '\t' {
+			atValueStart = c == separator || c == ':' || c == '['
+		}
+
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case separator:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if inQuote {
+		return nil, motmedelErrors.NewWithTrace(ErrUnterminatedQuote, s)
+	}
+
+	return append(parts, strings.TrimSpace(s[start:])), nil
+}
+
+// unquote returns the value without its enclosing single quotes, if any, and with escaped quotes unescaped.
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+// parseList parses a bracketed list of possibly quoted values, e.g. `[a, 'b,c']`.
+func parseList(value string) ([]string, error) {
+	inner, ok := strings.CutPrefix(value, "[")
+	if !ok {
+		return nil, motmedelErrors.NewWithTrace(ErrNotList, value)
+	}
+	inner, ok = strings.CutSuffix(inner, "]")
+	if !ok {
+		return nil, motmedelErrors.NewWithTrace(ErrNotList, value)
+	}
+
+	if strings.TrimSpace(inner) == "" {
+		return []string{}, nil
+	}
+
+	elements, err := split(inner, ',')
+	if err != nil {
+		return nil, fmt.Errorf("split: %w", err)
+	}
+
+	for i, element := range elements {
+		elements[i] = unquote(element)
+	}
+
+	return elements, nil
+}
+
+// ParseValue parses a value of the tag, such as an enum value, as a JSON value of the provided JSON Schema type.
+// Values of other types, or without a type, are strings.
+func ParseValue(value string, schemaType string) (any, error) {
+	switch schemaType {
+	case "integer":
+		integer, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv parse int: %w", err), value)
+		}
+		return integer, nil
+	case "number":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv parse float: %w", err), value)
+		}
+		return number, nil
+	case "boolean":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv parse bool: %w", err), value)
+		}
+		return boolean, nil
+	default:
+		return value, nil
+	}
+}
+
+// New parses a `jsonschema` tag, e.g. `jsonschema:"name,minlength:1,pattern:'^[a-z]{1,3}$',enum:[a,'b,c']"`. Option
+// keys are case-insensitive. Values containing commas or brackets are quoted with single quotes, and lists are
+// enclosed in brackets.
 func New(tagString string) (*Tag, error) {
 	trimmedTagString := strings.TrimSpace(tagString)
 	if trimmedTagString == "" {
@@ -30,7 +178,10 @@ func New(tagString string) (*Tag, error) {
 
 	var tag Tag
 
-	elements := strings.Split(strings.TrimSpace(trimmedTagString), ",")
+	elements, err := split(trimmedTagString, ',')
+	if err != nil {
+		return nil, fmt.Errorf("split: %w", err)
+	}
 	if len(elements) == 0 {
 		return nil, nil
 	}
@@ -43,16 +194,55 @@ func New(tagString string) (*Tag, error) {
 	tag.Name = elements[0]
 
 	for _, option := range elements[1:] {
-		option = strings.ToLower(strings.TrimSpace(option))
-		switch option {
+		switch strings.ToLower(option) {
 		case "optional":
 			tag.Optional = true
+		case "uniqueitems":
+			tag.UniqueItems = true
+		case "deprecated":
+			tag.Deprecated = true
+		case "readonly":
+			tag.ReadOnly = true
+		case "writeonly":
+			tag.WriteOnly = true
 		default:
 			key, value, ok := strings.Cut(option, ":")
 			if ok {
-				switch strings.ToLower(key) {
+				value = strings.TrimSpace(value)
+				switch strings.ToLower(strings.TrimSpace(key)) {
 				case "format":
-					tag.Format = value
+					tag.Format = strings.ToLower(unquote(value))
+					continue
+				case "pattern":
+					tag.Pattern = unquote(value)
+					continue
+				case "title":
+					tag.Title = unquote(value)
+					continue
+				case "description":
+					tag.Description = unquote(value)
+					continue
+				case "const":
+					constValue := unquote(value)
+					tag.Const = &constValue
+					continue
+				case "default":
+					defaultValue := unquote(value)
+					tag.Default = &defaultValue
+					continue
+				case "enum":
+					enum, err := parseList(value)
+					if err != nil {
+						return nil, fmt.Errorf("parse list (enum): %w", err)
+					}
+					tag.Enum = enum
+					continue
+				case "examples":
+					examples, err := parseList(value)
+					if err != nil {
+						return nil, fmt.Errorf("parse list (examples): %w", err)
+					}
+					tag.Examples = examples
 					continue
 				case "minlength":
 					minLength, err := strconv.Atoi(value)
@@ -82,23 +272,120 @@ func New(tagString string) (*Tag, error) {
 					}
 					tag.Maximum = &maximum
 					continue
+				case "exclusiveminimum":
+					exclusiveMinimum, err := strconv.ParseFloat(value, 64)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(
+							fmt.Errorf("strconv parse float (exclusiveminimum): %w", err),
+						)
+					}
+					tag.ExclusiveMinimum = &exclusiveMinimum
+					continue
+				case "exclusivemaximum":
+					exclusiveMaximum, err := strconv.ParseFloat(value, 64)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(
+							fmt.Errorf("strconv parse float (exclusivemaximum): %w", err),
+						)
+					}
+					tag.ExclusiveMaximum = &exclusiveMaximum
+					continue
+				case "multipleof":
+					multipleOf, err := strconv.ParseFloat(value, 64)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv parse float (multipleof): %w", err))
+					}
+					tag.MultipleOf = &multipleOf
+					continue
 				case "minitems":
 					minItems, err := strconv.Atoi(value)
 					if err != nil {
 						return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv atoi (minitems): %w", err))
 					}
 					tag.MinItems = &minItems
+					continue
 				case "maxitems":
 					maxItems, err := strconv.Atoi(value)
 					if err != nil {
 						return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv atoi (maxitems): %w", err))
 					}
 					tag.MaxItems = &maxItems
+					continue
+				case "minproperties":
+					minProperties, err := strconv.Atoi(value)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv atoi (minproperties): %w", err))
+					}
+					tag.MinProperties = &minProperties
+					continue
+				case "maxproperties":
+					maxProperties, err := strconv.Atoi(value)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(fmt.Errorf("strconv atoi (maxproperties): %w", err))
+					}
+					tag.MaxProperties = &maxProperties
+					continue
+				case "additionalproperties":
+					additionalProperties, err := strconv.ParseBool(value)
+					if err != nil {
+						return nil, motmedelErrors.NewWithTrace(
+							fmt.Errorf("strconv parse bool (additionalproperties): %w", err),
+						)
+					}
+					tag.AdditionalProperties = &additionalProperties
+					continue
 				}
 			}
-			tag.OtherOptions = append(tag.OtherOptions, option)
+			tag.OtherOptions = append(tag.OtherOptions, strings.ToLower(option))
 		}
 	}
 
 	return &tag, nil
 }
+
+// Validate reports the unknown options of the tag, and options that conflict with each other.
+func (t *Tag) Validate() error {
+	var errs []error
+
+	for _, option := range t.OtherOptions {
+		if option != "" {
+			errs = append(
+				errs,
+				motmedelErrors.NewWithTrace(fmt.Errorf("%w: %s", typeGenerationErrors.ErrUnknownTagOption, option)),
+			)
+		}
+	}
+
+	conflict := func(description string) {
+		errs = append(
+			errs,
+			motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %s", typeGenerationErrors.ErrConflictingTagOptions, description),
+			),
+		)
+	}
+
+	if t.MinLength != nil && t.MaxLength != nil && *t.MinLength > *t.MaxLength {
+		conflict("minlength is greater than maxlength")
+	}
+	if t.Minimum != nil && t.Maximum != nil && *t.Minimum > *t.Maximum {
+		conflict("minimum is greater than maximum")
+	}
+	if t.ExclusiveMinimum != nil && t.ExclusiveMaximum != nil && *t.ExclusiveMinimum >= *t.ExclusiveMaximum {
+		conflict("exclusiveminimum is not less than exclusivemaximum")
+	}
+	if t.MinItems != nil && t.MaxItems != nil && *t.MinItems > *t.MaxItems {
+		conflict("minitems is greater than maxitems")
+	}
+	if t.MinProperties != nil && t.MaxProperties != nil && *t.MinProperties > *t.MaxProperties {
+		conflict("minproperties is greater than maxproperties")
+	}
+	if t.MultipleOf != nil && *t.MultipleOf <= 0 {
+		conflict("multipleof is not positive")
+	}
+	if t.ReadOnly && t.WriteOnly {
+		conflict("readonly and writeonly")
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,7 +1,6 @@
 package types
 
 import (
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -10,59 +9,180 @@ import (
 	"github.com/Motmedel/utils_go/pkg/errors/types/nil_error"
 	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
 	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
-	"github.com/Motmedel/utils_go/pkg/utils"
 	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/schema"
 	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
 	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
 	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
 )
 
+const (
+	integerPattern         = "^-?[0-9]+$"
+	unsignedIntegerPattern = "^[0-9]+$"
+	numberPattern          = `^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`
+)
+
+// Options controls the constraints that the producer adds to the schemas of all types, beyond those of the
+// `jsonschema` tags. The zero value adds none of them.
+type Options struct {
+	// NonEmptyStrings describes strings with a minimum length of 1, unless a tag specifies another minimum length.
+	NonEmptyStrings bool
+	// NonEmptyArrays describes arrays with a minimum number of items of 1, unless a tag specifies another minimum.
+	NonEmptyArrays bool
+	// DisallowAdditionalProperties describes objects as not having properties other than those of their fields. A
+	// type overrides this with the `additionalproperties` option in the `jsonschema` tag of a `_` field, e.g.
+	// `_ struct{} jsonschema:",additionalproperties:true"`.
+	DisallowAdditionalProperties bool
+}
+
+// StrictOptions returns options that describe strings and arrays as non-empty and objects as not having additional
+// properties.
+func StrictOptions() Options {
+	return Options{NonEmptyStrings: true, NonEmptyArrays: true, DisallowAdditionalProperties: true}
+}
+
+// LenientOptions returns options that add no constraints beyond those of the tags.
+func LenientOptions() Options {
+	return Options{}
+}
+
+// hasTypeMapping reports whether the type mapping has a JSON Schema representation, in which case the mapped type is
+// not rendered as a declaration.
+func hasTypeMapping(typeMapping *type_mapping.TypeMapping) bool {
+	return typeMapping.JSONSchema != nil
+}
+
 type Context struct {
 	*typeGenerationContext.Context
+	Options
+
+	// Int64AsString describes 64-bit integers as strings of digits, for APIs that encode them as strings to not lose
+	// precision in JSON parsers that represent numbers as doubles.
+	Int64AsString bool
+	// Strict rejects interface types that are not registered as tagged unions instead of describing them as any value.
+	Strict bool
+	// StrictTags makes building schemas fail if the `jsonschema` tags of fields have problems, as reported by LintTag.
+	StrictTags bool
+	// Draft determines the JSON Schema draft that the rendered schemas conform to.
+	Draft Draft
+	// BaseURI is the URI relative to which RenderFiles derives the `$id` of each file, e.g.
+	// "https://example.com/schemas/". Without a base URI, the files have no `$id`, and references between them are
+	// resolved relative to the URIs from which they are retrieved.
+	BaseURI string
+	// Indent indents the rendered documents with the string per level, e.g. two spaces. Without it, the documents are
+	// rendered on one line.
+	Indent string
+
+	// referenceFiles makes references refer to the files of RenderFiles instead of definitions in the same document.
+	referenceFiles bool
+}
+
+func isUnsigned(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// integerStringSchema returns a schema describing an integer of the provided kind encoded as a string.
+func integerStringSchema(kind reflect.Kind) *schema.Schema {
+	if isUnsigned(kind) {
+		return &schema.Schema{Type: schema.Type{"string"}, Pattern: unsignedIntegerPattern}
+	}
+	return &schema.Schema{Type: schema.Type{"string"}, Pattern: integerPattern}
+}
+
+// quotedSchema returns a schema describing a value of the provided type encoded inside a JSON string, as with the
+// `string` option of the `json` tag.
+func quotedSchema(reflectType reflect.Type) *schema.Schema {
+	switch kind := reflectType.Kind(); kind {
+	case reflect.Bool:
+		return &schema.Schema{Type: schema.Type{"string"}, Enum: []any{"true", "false"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return integerStringSchema(kind)
+	case reflect.Float32, reflect.Float64:
+		return &schema.Schema{Type: schema.Type{"string"}, Pattern: numberPattern}
+	default:
+		return &schema.Schema{Type: schema.Type{"string"}}
+	}
 }
 
 func isTime(t reflect.Type) bool {
 	return t.Name() == "Time" && t.PkgPath() == "time"
 }
 
+// singleType returns the type of a schema with exactly one type, or an empty string.
+func singleType(s *schema.Schema) string {
+	if len(s.Type) != 1 {
+		return ""
+	}
+	return s.Type[0]
+}
+
 // GetJSONSchemaType returns a JSON Schema fragment describing the provided type.
-func (c *Context) GetJSONSchemaType(reflectType reflect.Type) (map[string]any, error) {
+func (c *Context) GetJSONSchemaType(reflectType reflect.Type) (*schema.Schema, error) {
 	reflectType = motmedelReflect.RemoveIndirection(reflectType)
 
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.JSONSchema != nil {
+		mappedSchema, err := schema.FromMap(typeMapping.JSONSchema)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("schema from map (type mapping): %w", err), reflectType)
+		}
+		return mappedSchema, nil
+	}
+
+	if !isTime(reflectType) {
+		switch typeGenerationContext.GetMarshalerKind(reflectType) {
+		case typeGenerationContext.MarshalerKindText:
+			return &schema.Schema{Type: schema.Type{"string"}}, nil
+		case typeGenerationContext.MarshalerKindJSON:
+			// The encoding is unknown; accept any value.
+			return &schema.Schema{}, nil
+		default:
+		}
+	}
+
 	switch kind := reflectType.Kind(); kind {
 	case reflect.Struct:
 		if isTime(reflectType) {
-			return map[string]any{"type": "string", "format": "date-time"}, nil
+			return &schema.Schema{Type: schema.Type{"string"}, Format: "date-time"}, nil
 		}
 
-		// Reference another interface via local $defs
+		// Reference the definition of another interface.
 		typeDeclaration, ok := c.TypeDeclarations[reflectType]
 		if ok {
 			if iface, ok2 := typeDeclaration.(*type_declaration.InterfaceDeclaration); ok2 {
-				return map[string]any{"$ref": "#/$defs/" + iface.QualifiedName()}, nil
+				return &schema.Schema{Ref: c.reference(c.InstantiationName(iface))}, nil
 			}
 		}
 		return nil, motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, kind)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return map[string]any{"type": "integer"}, nil
+		if c.Int64AsString && reflectType.Bits() == 64 {
+			return integerStringSchema(kind), nil
+		}
+		return &schema.Schema{Type: schema.Type{"integer"}}, nil
 	case reflect.Float32, reflect.Float64:
-		return map[string]any{"type": "number"}, nil
+		return &schema.Schema{Type: schema.Type{"number"}}, nil
 	case reflect.String:
-		return map[string]any{"type": "string"}, nil
+		return &schema.Schema{Type: schema.Type{"string"}}, nil
 	case reflect.Bool:
-		return map[string]any{"type": "boolean"}, nil
+		return &schema.Schema{Type: schema.Type{"boolean"}}, nil
 	case reflect.Slice, reflect.Array:
-		// Special case: []byte -> base64 string
-		elem := motmedelReflect.RemoveIndirection(reflectType.Elem())
-		if elem.Kind() == reflect.Uint8 {
-			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		// Special case: []byte -> base64 string. As with encoding/json, byte arrays are encoded as arrays.
+		if kind == reflect.Slice && reflectType.Elem().Kind() == reflect.Uint8 {
+			return &schema.Schema{Type: schema.Type{"string"}, ContentEncoding: "base64"}, nil
 		}
+		elem := motmedelReflect.RemoveIndirection(reflectType.Elem())
 		itemSchema, err := c.GetJSONSchemaType(elem)
 		if err != nil {
 			return nil, motmedelErrors.New(fmt.Errorf("get json schema type (items): %w", err), elem)
 		}
-		return map[string]any{"type": "array", "items": itemSchema}, nil
+		return &schema.Schema{Type: schema.Type{"array"}, Items: itemSchema}, nil
 	case reflect.Map:
 		// JSON object with additionalProperties as value schema
 		value := motmedelReflect.RemoveIndirection(reflectType.Elem())
@@ -70,9 +190,20 @@ func (c *Context) GetJSONSchemaType(reflectType reflect.Type) (map[string]any, e
 		if err != nil {
 			return nil, motmedelErrors.New(fmt.Errorf("get json schema type (map value): %w", err), value)
 		}
-		return map[string]any{"type": "object", "additionalProperties": valueSchema}, nil
+		return &schema.Schema{Type: schema.Type{"object"}, AdditionalProperties: valueSchema}, nil
 	case reflect.Pointer:
 		return c.GetJSONSchemaType(reflectType.Elem())
+	case reflect.Interface:
+		if unionDeclaration := c.GetUnionDeclaration(reflectType); unionDeclaration != nil {
+			return &schema.Schema{Ref: c.reference(unionDeclaration.QualifiedName())}, nil
+		}
+		if c.Strict {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind,
+			)
+		}
+		// The dynamic type is unknown; accept any value.
+		return &schema.Schema{}, nil
 	default:
 		return nil, motmedelErrors.NewWithTrace(
 			fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind,
@@ -80,16 +211,201 @@ func (c *Context) GetJSONSchemaType(reflectType reflect.Type) (map[string]any, e
 	}
 }
 
-// buildInterfaceSchema builds the object schema for a given interface declaration
-func (c *Context) buildInterfaceSchema(interfaceDeclaration *type_declaration.InterfaceDeclaration) (map[string]any, error) {
-	schemaMap := map[string]any{
-		"type": "object",
+// applyTag applies the constraints and annotations of a jsonschema tag to a property schema. Constraints are only
+// applied to schemas of the types to which they pertain; values, such as enum values, are parsed as values of the
+// schema's type.
+func applyTag(propertySchema *schema.Schema, jsonschemaTag *tag.Tag) error {
+	// explicit format (overrides any inferred format, e.g., time.Time)
+	if f := strings.TrimSpace(jsonschemaTag.Format); f != "" {
+		propertySchema.Format = f
 	}
 
-	properties := map[string]any{}
-	var requiredProperties []string
-	// TODO: Should this be anything other than false? Control with a `_` field?
-	var additionalProps any = false
+	schemaType := singleType(propertySchema)
+
+	switch schemaType {
+	case "string":
+		if minLength := jsonschemaTag.MinLength; minLength != nil {
+			propertySchema.MinLength = minLength
+		}
+		if maxLength := jsonschemaTag.MaxLength; maxLength != nil {
+			propertySchema.MaxLength = maxLength
+		}
+		if pattern := jsonschemaTag.Pattern; pattern != "" {
+			propertySchema.Pattern = pattern
+		}
+	case "number", "integer":
+		if minimum := jsonschemaTag.Minimum; minimum != nil {
+			propertySchema.Minimum = minimum
+		}
+		if maximum := jsonschemaTag.Maximum; maximum != nil {
+			propertySchema.Maximum = maximum
+		}
+		if exclusiveMinimum := jsonschemaTag.ExclusiveMinimum; exclusiveMinimum != nil {
+			propertySchema.ExclusiveMinimum = exclusiveMinimum
+		}
+		if exclusiveMaximum := jsonschemaTag.ExclusiveMaximum; exclusiveMaximum != nil {
+			propertySchema.ExclusiveMaximum = exclusiveMaximum
+		}
+		if multipleOf := jsonschemaTag.MultipleOf; multipleOf != nil {
+			propertySchema.MultipleOf = multipleOf
+		}
+	case "array":
+		if minItems := jsonschemaTag.MinItems; minItems != nil {
+			propertySchema.MinItems = minItems
+		}
+		if maxItems := jsonschemaTag.MaxItems; maxItems != nil {
+			propertySchema.MaxItems = maxItems
+		}
+		if jsonschemaTag.UniqueItems {
+			propertySchema.UniqueItems = true
+		}
+	case "object":
+		if minProperties := jsonschemaTag.MinProperties; minProperties != nil {
+			propertySchema.MinProperties = minProperties
+		}
+		if maxProperties := jsonschemaTag.MaxProperties; maxProperties != nil {
+			propertySchema.MaxProperties = maxProperties
+		}
+	}
+
+	parseValues := func(values []string) ([]any, error) {
+		parsedValues := make([]any, 0, len(values))
+		for _, value := range values {
+			parsedValue, err := tag.ParseValue(value, schemaType)
+			if err != nil {
+				return nil, fmt.Errorf("parse value: %w", err)
+			}
+			parsedValues = append(parsedValues, parsedValue)
+		}
+		return parsedValues, nil
+	}
+
+	if jsonschemaTag.Enum != nil {
+		enum, err := parseValues(jsonschemaTag.Enum)
+		if err != nil {
+			return fmt.Errorf("parse values (enum): %w", err)
+		}
+		propertySchema.Enum = enum
+	}
+	if jsonschemaTag.Const != nil {
+		constValue, err := tag.ParseValue(*jsonschemaTag.Const, schemaType)
+		if err != nil {
+			return fmt.Errorf("parse value (const): %w", err)
+		}
+		propertySchema.Const = constValue
+	}
+	if jsonschemaTag.Default != nil {
+		defaultValue, err := tag.ParseValue(*jsonschemaTag.Default, schemaType)
+		if err != nil {
+			return fmt.Errorf("parse value (default): %w", err)
+		}
+		propertySchema.Default = defaultValue
+	}
+	if jsonschemaTag.Examples != nil {
+		examples, err := parseValues(jsonschemaTag.Examples)
+		if err != nil {
+			return fmt.Errorf("parse values (examples): %w", err)
+		}
+		propertySchema.Examples = examples
+	}
+
+	if title := jsonschemaTag.Title; title != "" {
+		propertySchema.Title = title
+	}
+	if description := jsonschemaTag.Description; description != "" {
+		propertySchema.Description = description
+	}
+	if jsonschemaTag.Deprecated {
+		propertySchema.Deprecated = true
+	}
+	if jsonschemaTag.ReadOnly {
+		propertySchema.ReadOnly = true
+	}
+	if jsonschemaTag.WriteOnly {
+		propertySchema.WriteOnly = true
+	}
+
+	return nil
+}
+
+// nullableSchema extends a schema to also accept `null`, using a type list if the schema has a single type and
+// `anyOf` otherwise (e.g. for `$ref` schemas and schemas with a `const`).
+func nullableSchema(propertySchema *schema.Schema) *schema.Schema {
+	if t := singleType(propertySchema); t != "" && propertySchema.Const == nil {
+		propertySchema.Type = schema.Type{t, "null"}
+		// The enum restricts the values regardless of the type.
+		if propertySchema.Enum != nil {
+			propertySchema.Enum = append(propertySchema.Enum, nil)
+		}
+		return propertySchema
+	}
+
+	return &schema.Schema{AnyOf: []*schema.Schema{propertySchema, {Type: schema.Type{"null"}}}}
+}
+
+// buildUnionSchema builds the schema of a tagged union, which matches exactly one of the variants, each with its
+// discriminator property constrained to the variant's tag.
+func (c *Context) buildUnionSchema(unionDeclaration *type_declaration.UnionDeclaration) (*schema.Schema, error) {
+	var variantSchemas []*schema.Schema
+	for _, variant := range unionDeclaration.Variants {
+		if variant == nil || variant.InterfaceDeclaration == nil {
+			return nil, motmedelErrors.NewWithTrace(nil_error.New("union variant interface declaration"), unionDeclaration)
+		}
+
+		properties := schema.NewMap()
+		properties.Set(unionDeclaration.Discriminator, &schema.Schema{Const: variant.Tag})
+
+		variantSchemas = append(
+			variantSchemas,
+			c.isolateReference(
+				&schema.Schema{
+					Ref:        c.reference(c.InstantiationName(variant.InterfaceDeclaration)),
+					Properties: properties,
+					Required:   []string{unionDeclaration.Discriminator},
+				},
+			),
+		)
+	}
+
+	return &schema.Schema{OneOf: variantSchemas}, nil
+}
+
+// additionalPropertiesOverride returns whether the `jsonschema` tag of a `_` field of the struct type allows additional
+// properties, or nil if no such field has the `additionalproperties` option.
+func additionalPropertiesOverride(structType reflect.Type) (*bool, error) {
+	if structType == nil {
+		return nil, nil
+	}
+
+	structType = motmedelReflect.RemoveIndirection(structType)
+	if structType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+
+		rawJsonSchemaTag := field.Tag.Get("jsonschema")
+		jsonschemaTag, err := tag.New(rawJsonSchemaTag)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("jsonschema tag new: %w", err), rawJsonSchemaTag)
+		}
+		if jsonschemaTag != nil && jsonschemaTag.AdditionalProperties != nil {
+			return jsonschemaTag.AdditionalProperties, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// buildInterfaceSchema builds the object schema for a given interface declaration
+func (c *Context) buildInterfaceSchema(
+	interfaceDeclaration *type_declaration.InterfaceDeclaration,
+) (*schema.Schema, error) {
+	interfaceSchema := &schema.Schema{Type: schema.Type{"object"}, Properties: schema.NewMap(), Required: []string{}}
 
 	for _, property := range interfaceDeclaration.Properties {
 		if property == nil {
@@ -101,9 +417,23 @@ func (c *Context) buildInterfaceSchema(interfaceDeclaration *type_declaration.In
 			return nil, motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
 		}
 
-		identifier := property.Identifier
+		identifier := c.PropertyNameConvention.Apply(property.Identifier)
 		isOptional := property.Optional
 
+		if c.StrictTags {
+			if err := c.LintTag(*field); err != nil {
+				return nil, motmedelErrors.NewWithTrace(
+					fmt.Errorf(
+						"%w: %s.%s: %w",
+						typeGenerationErrors.ErrInvalidTag,
+						interfaceDeclaration.ReflectType,
+						field.Name,
+						err,
+					),
+				)
+			}
+		}
+
 		rawJsonSchemaTag := field.Tag.Get("jsonschema")
 		jsonschemaTag, err := tag.New(rawJsonSchemaTag)
 		if err != nil {
@@ -144,124 +474,49 @@ func (c *Context) buildInterfaceSchema(interfaceDeclaration *type_declaration.In
 		if err != nil {
 			return nil, motmedelErrors.New(fmt.Errorf("get json schema type: %w", err), fieldType)
 		}
+		// The empty schema, describing any value, already accepts `null`.
+		acceptsAnyValue := propertySchema.IsEmpty()
 
-		if t, ok := propertySchema["type"].(string); ok {
-			switch t {
-			case "string":
-				propertySchema["minLength"] = 1
-			case "array":
-				propertySchema["minItems"] = 1
-			}
+		if property.Quoted {
+			propertySchema = quotedSchema(motmedelReflect.RemoveIndirection(fieldType))
+		}
+
+		nonEmpty := 1
+		switch t := singleType(propertySchema); {
+		case t == "string" && c.NonEmptyStrings:
+			propertySchema.MinLength = &nonEmpty
+		case t == "array" && c.NonEmptyArrays:
+			propertySchema.MinItems = &nonEmpty
 		}
 
 		// Apply constraints from the jsonschema tag.
 		if jsonschemaTag != nil {
-			// explicit format (overrides any inferred format, e.g., time.Time)
-			if f := strings.TrimSpace(jsonschemaTag.Format); f != "" {
-				propertySchema["format"] = f
+			if err := applyTag(propertySchema, jsonschemaTag); err != nil {
+				return nil, motmedelErrors.New(fmt.Errorf("apply tag: %w", err), rawJsonSchemaTag)
 			}
+		}
+		propertySchema = c.isolateReference(propertySchema)
 
-			if t, ok := propertySchema["type"].(string); ok {
-				switch t {
-				case "string":
-					if minLength := jsonschemaTag.MinLength; minLength != nil {
-						propertySchema["minLength"] = *minLength
-					}
-					if maxLength := jsonschemaTag.MaxLength; maxLength != nil {
-						propertySchema["maxLength"] = *maxLength
-					}
-				case "number", "integer":
-					if minimum := jsonschemaTag.Minimum; minimum != nil {
-						propertySchema["minimum"] = *minimum
-					}
-					if maximum := jsonschemaTag.Maximum; maximum != nil {
-						propertySchema["maximum"] = *maximum
-					}
-				case "array":
-					if minItems := jsonschemaTag.MinItems; minItems != nil {
-						propertySchema["minItems"] = *minItems
-					}
-					if maxItems := jsonschemaTag.MaxItems; maxItems != nil {
-						propertySchema["maxItems"] = *maxItems
-					}
-				}
-			}
+		if property.Nullable && !acceptsAnyValue {
+			propertySchema = nullableSchema(propertySchema)
 		}
 
-		properties[identifier] = propertySchema
+		interfaceSchema.Properties.Set(identifier, propertySchema)
 		if !isOptional {
-			requiredProperties = append(requiredProperties, identifier)
+			interfaceSchema.Required = append(interfaceSchema.Required, identifier)
 		}
 	}
 
-	schemaMap["properties"] = properties
-	if len(requiredProperties) > 0 {
-		schemaMap["required"] = requiredProperties
-	} else {
-		schemaMap["required"] = []string{}
-	}
-
-	schemaMap["additionalProperties"] = additionalProps
-
-	return schemaMap, nil
-}
-
-// RenderRoot builds a single JSON Schema document with the provided root type as the top-level schema
-// and all discovered interfaces included under $defs. References use local $refs to $defs.
-func (c *Context) RenderRoot(root reflect.Type) (string, error) {
-	root = motmedelReflect.RemoveIndirection(root)
-
-	rootKind := root.Kind()
-	if rootKind != reflect.Struct {
-		return "", motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, rootKind)
-	}
-
-	rootTypeDeclaration, ok := c.TypeDeclarations[root]
-	if !ok {
-		return "", motmedelErrors.NewWithTrace(
-			fmt.Errorf("%w (root type)", motmedelErrors.ErrNotInMap),
-			root,
-		)
-	}
-
-	rootInterfaceDeclaration, err := utils.ConvertToNonZero[*type_declaration.InterfaceDeclaration](rootTypeDeclaration)
+	additionalProperties, err := additionalPropertiesOverride(interfaceDeclaration.ReflectType)
 	if err != nil {
-		return "", motmedelErrors.New(
-			fmt.Errorf("convert to non zero (root type declaration): %w", err),
-			rootTypeDeclaration,
-		)
-	}
-
-	// Build $defs for all interfaces
-	defs := map[string]any{}
-	for _, typeDeclaration := range c.TypeDeclarationsInOrder {
-		interfaceDeclaration, ok := typeDeclaration.(*type_declaration.InterfaceDeclaration)
-		if !ok || interfaceDeclaration == nil {
-			continue
-		}
-
-		schema, err := c.buildInterfaceSchema(interfaceDeclaration)
-		if err != nil {
-			return "", motmedelErrors.New(fmt.Errorf("build interface schema: %w", err), interfaceDeclaration)
-		}
-
-		defs[interfaceDeclaration.Identifier] = schema
+		return nil, fmt.Errorf("additional properties override: %w", err)
 	}
-
-	rootInterfaceDeclarationIdentifier := rootInterfaceDeclaration.Identifier
-
-	schemaMap := map[string]any{
-		"$schema": "https://json-schema.org/draft/2020-12/schema",
-		"title":   rootInterfaceDeclarationIdentifier,
-		"$defs":   defs,
-	}
-	// Reference the root schema via $defs to avoid duplicating the object at the top level
-	schemaMap["$ref"] = "#/$defs/" + rootInterfaceDeclarationIdentifier
-
-	data, err := json.Marshal(schemaMap)
-	if err != nil {
-		return "", motmedelErrors.NewWithTrace(fmt.Errorf("json marshal (schema map): %w", err), schemaMap)
+	switch {
+	case additionalProperties != nil:
+		interfaceSchema.AdditionalProperties = schema.Boolean(*additionalProperties)
+	case c.DisallowAdditionalProperties:
+		interfaceSchema.AdditionalProperties = schema.Boolean(false)
 	}
 
-	return string(data), nil
+	return interfaceSchema, nil
 }
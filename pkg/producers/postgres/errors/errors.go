@@ -1,7 +0,0 @@
-package errors
-
-import "errors"
-
-var (
-	ErrGenericTypesUnsupported = errors.New("generic types unsupported")
-)
@@ -25,6 +25,7 @@ const (
 	Timestamp       = BasicType("timestamptz")
 	ByteA           = BasicType("bytea")
 	CiText          = BasicType("citext")
+	JSONB           = BasicType("jsonb")
 )
 
 type TypeReference struct {
@@ -1,6 +1,13 @@
 package tag
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+)
 
 type Tag struct {
 	Name            string
@@ -143,3 +150,36 @@ func New(tagString string) *Tag {
 
 	return &tag
 }
+
+// Validate reports the unknown options of the tag, and options that conflict with each other.
+func (t *Tag) Validate() error {
+	var errs []error
+
+	for _, option := range t.OtherOptions {
+		errs = append(
+			errs,
+			motmedelErrors.NewWithTrace(fmt.Errorf("%w: %s", typeGenerationErrors.ErrUnknownTagOption, option)),
+		)
+	}
+
+	conflict := func(description string) {
+		errs = append(
+			errs,
+			motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %s", typeGenerationErrors.ErrConflictingTagOptions, description),
+			),
+		)
+	}
+
+	if t.Nullable && t.PrimaryKey {
+		conflict("nullable and primarykey")
+	}
+	if t.Generated != "" && t.GeneratedStored != "" {
+		conflict("generated and generatedstored")
+	}
+	if t.Default != "" && (t.Generated != "" || t.GeneratedStored != "") {
+		conflict("default and a generated column")
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,9 +1,9 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
 	"slices"
 	"strings"
 
@@ -12,23 +12,13 @@ import (
 	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
 	"github.com/Motmedel/utils_go/pkg/utils"
 	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
-	postgresErrors "github.com/vphpersson/type_generation/pkg/producers/postgres/errors"
 	"github.com/vphpersson/type_generation/pkg/producers/postgres/types/tag"
 	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
 	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
 )
 
-// TODO: Move.
-
-var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
-var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
-
-func toSnakeCase(s string) string {
-	s = matchFirstCap.ReplaceAllString(s, "${1}_${2}")
-	s = matchAllCap.ReplaceAllString(s, "${1}_${2}")
-	return strings.ToLower(s)
-}
-
 func resolveIdType(interfaceDeclaration *InterfaceDeclaration) (string, error) {
 	if interfaceDeclaration == nil || interfaceDeclaration.InterfaceDeclaration == nil || interfaceDeclaration.c == nil {
 		return "", nil
@@ -44,7 +34,7 @@ func resolveIdType(interfaceDeclaration *InterfaceDeclaration) (string, error) {
 			continue
 		}
 
-		identifier := property.Identifier
+		identifier := interfaceDeclaration.c.PropertyNameConvention.Apply(property.Identifier)
 		var typeString string
 
 		postgresTag := tag.New(propertyField.Tag.Get("postgres"))
@@ -140,13 +130,82 @@ func isTime(t reflect.Type) bool {
 	return t.Name() == "Time" && t.PkgPath() == "time"
 }
 
+// hasTypeMapping reports whether the type mapping has a Postgres representation, in which case the mapped type is not
+// rendered as a declaration.
+func hasTypeMapping(typeMapping *type_mapping.TypeMapping) bool {
+	return typeMapping.Postgres != ""
+}
+
 type Context struct {
 	*typeGenerationContext.Context
+	// StrictTags makes rendering fail if the `postgres` tags of fields have problems, as reported by LintTag.
+	StrictTags bool
+}
+
+// isReference reports whether the column of a field of the type references the table of another struct type.
+func (c *Context) isReference(reflectType reflect.Type) bool {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && hasTypeMapping(typeMapping) {
+		return false
+	}
+
+	return reflectType.Kind() == reflect.Struct && !isTime(reflectType) &&
+		typeGenerationContext.GetMarshalerKind(reflectType) == typeGenerationContext.MarshalerKindNone
+}
+
+// LintTag reports the problems of the `postgres` tag of a field: unknown options, options that conflict with each
+// other, and referential actions of columns that do not reference another table.
+func (c *Context) LintTag(field reflect.StructField) error {
+	postgresTag := tag.New(field.Tag.Get("postgres"))
+	if postgresTag == nil {
+		return nil
+	}
+
+	errs := []error{postgresTag.Validate()}
+
+	isReference := c.isReference(field.Type)
+	for _, check := range []struct {
+		option  string
+		present bool
+	}{
+		{"ondelete", postgresTag.OnDelete != ""},
+		{"onupdate", postgresTag.OnUpdate != ""},
+	} {
+		if check.present && !isReference {
+			errs = append(
+				errs,
+				motmedelErrors.NewWithTrace(
+					fmt.Errorf(
+						"%w: %s does not apply to %s",
+						typeGenerationErrors.ErrInapplicableTagOption,
+						check.option,
+						field.Type,
+					),
+				),
+			)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (c *Context) GetPostgresType(reflectType reflect.Type) (Type, error) {
 	reflectType = motmedelReflect.RemoveIndirection(reflectType)
 
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.Postgres != "" {
+		return BasicType(typeMapping.Postgres), nil
+	}
+
+	if !isTime(reflectType) {
+		switch typeGenerationContext.GetMarshalerKind(reflectType) {
+		case typeGenerationContext.MarshalerKindText:
+			return Text, nil
+		case typeGenerationContext.MarshalerKindJSON:
+			return JSONB, nil
+		default:
+		}
+	}
+
 	var postgresType Type
 
 	switch kind := reflectType.Kind(); kind {
@@ -164,10 +223,6 @@ func (c *Context) GetPostgresType(reflectType reflect.Type) (Type, error) {
 				return nil, motmedelErrors.New(fmt.Errorf("convert: %w", err), typeDeclaration)
 			}
 
-			if genericTypeInfo := interfaceDeclaration.GenericTypeInfo; genericTypeInfo != nil {
-				return nil, motmedelErrors.NewWithTrace(postgresErrors.ErrGenericTypesUnsupported)
-			}
-
 			postgresType = (&InterfaceDeclaration{InterfaceDeclaration: interfaceDeclaration, c: c}).TypeReference()
 		}
 	case reflect.Int8, reflect.Uint8, reflect.Int16, reflect.Uint16:
@@ -201,6 +256,12 @@ func (c *Context) GetPostgresType(reflectType reflect.Type) (Type, error) {
 		} else {
 			postgresType = &ArrayType{ItemsType: itemPostgresType}
 		}
+	case reflect.Interface:
+		// The variants of a tagged union have different columns, thus the value is stored as a document.
+		if c.GetUnionDeclaration(reflectType) == nil {
+			return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind)
+		}
+		postgresType = JSONB
 	default:
 		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind)
 	}
@@ -208,10 +269,106 @@ func (c *Context) GetPostgresType(reflectType reflect.Type) (Type, error) {
 	return postgresType, nil
 }
 
+// references returns the declarations of the tables that the table references, either with the columns of its fields
+// or with its associative tables.
+func (t *InterfaceDeclaration) references() ([]*type_declaration.InterfaceDeclaration, error) {
+	var references []*type_declaration.InterfaceDeclaration
+
+	for _, property := range t.Properties {
+		if property == nil || property.Field == nil {
+			continue
+		}
+
+		postgresType, err := t.c.GetPostgresType(property.Field.Type)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("context get postgres type: %w", err), property.Field.Type)
+		}
+
+		var referenced type_declaration.TypeDeclaration
+		switch v := postgresType.(type) {
+		case *AssociativeTable:
+			referenced = v.Target
+		case *TypeReference:
+			// A skipped field has no column, and a column with a type from the tag does not reference the table.
+			if postgresTag := tag.New(property.Field.Tag.Get("postgres")); postgresTag != nil &&
+				(postgresTag.Skip || postgresTag.Type != "") {
+				continue
+			}
+			referenced = v.TypeDeclaration
+		default:
+			continue
+		}
+
+		if interfaceDeclaration, ok := referenced.(*InterfaceDeclaration); ok && interfaceDeclaration != nil {
+			references = append(references, interfaceDeclaration.InterfaceDeclaration)
+		}
+	}
+
+	return references, nil
+}
+
+// sortTables sorts the tables topologically, such that each table is created after the tables that it references.
+// Of the tables whose referenced tables have been created, the one first in the ordering of the context is created
+// first. Tables within reference cycles are created in that ordering.
+func sortTables(interfaceDeclarations []*InterfaceDeclaration) ([]*InterfaceDeclaration, error) {
+	remaining := map[*type_declaration.InterfaceDeclaration]struct{}{}
+	for _, interfaceDeclaration := range interfaceDeclarations {
+		remaining[interfaceDeclaration.InterfaceDeclaration] = struct{}{}
+	}
+
+	tableReferences := make([][]*type_declaration.InterfaceDeclaration, len(interfaceDeclarations))
+	for i, interfaceDeclaration := range interfaceDeclarations {
+		references, err := interfaceDeclaration.references()
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("references: %w", err), interfaceDeclaration)
+		}
+		tableReferences[i] = references
+	}
+
+	isReady := func(i int) bool {
+		for _, reference := range tableReferences[i] {
+			if _, ok := remaining[reference]; ok && reference != interfaceDeclarations[i].InterfaceDeclaration {
+				return false
+			}
+		}
+		return true
+	}
+
+	sorted := make([]*InterfaceDeclaration, 0, len(interfaceDeclarations))
+	for len(sorted) < len(interfaceDeclarations) {
+		next := -1
+		for i, interfaceDeclaration := range interfaceDeclarations {
+			if _, ok := remaining[interfaceDeclaration.InterfaceDeclaration]; !ok {
+				continue
+			}
+			if next == -1 {
+				next = i
+			}
+			if isReady(i) {
+				next = i
+				break
+			}
+		}
+
+		sorted = append(sorted, interfaceDeclarations[next])
+		delete(remaining, interfaceDeclarations[next].InterfaceDeclaration)
+	}
+
+	return sorted, nil
+}
+
+// Render renders the tables of the context. Tables are created after the tables that they reference; the ordering of
+// the context orders the tables otherwise.
 func (c *Context) Render() (string, error) {
 	var interfaceDeclarations []*InterfaceDeclaration
 
-	for _, typeDeclaration := range c.TypeDeclarationsInOrder {
+	typeDeclarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return "", fmt.Errorf("ordered type declarations: %w", err)
+	}
+	typeDeclarations = c.RemoveMappedTypeDeclarations(typeDeclarations, hasTypeMapping)
+
+	for _, typeDeclaration := range typeDeclarations {
 		switch v := any(typeDeclaration).(type) {
 		case *type_declaration.InterfaceDeclaration:
 			interfaceDeclarations = append(
@@ -221,6 +378,11 @@ func (c *Context) Render() (string, error) {
 		}
 	}
 
+	interfaceDeclarations, err = sortTables(interfaceDeclarations)
+	if err != nil {
+		return "", fmt.Errorf("sort tables: %w", err)
+	}
+
 	var stringBuilder strings.Builder
 
 	for i, interfaceDeclaration := range interfaceDeclarations {
@@ -244,11 +406,6 @@ type InterfaceDeclaration struct {
 }
 
 func (t *InterfaceDeclaration) String() (string, error) {
-	genericTypeInfo := t.GenericTypeInfo
-	if genericTypeInfo != nil {
-		return "", motmedelErrors.NewWithTrace(postgresErrors.ErrGenericTypesUnsupported)
-	}
-
 	var associativeTables []string
 	var indices []string
 
@@ -291,10 +448,18 @@ func (t *InterfaceDeclaration) String() (string, error) {
 			return "", fmt.Errorf("type string: %w", err)
 		}
 
-		identifier := property.Identifier
+		identifier := t.c.PropertyNameConvention.Apply(property.Identifier)
 		var attributes []string
 		optional := property.Optional
 
+		if t.c.StrictTags {
+			if err := t.c.LintTag(*field); err != nil {
+				return "", motmedelErrors.NewWithTrace(
+					fmt.Errorf("%w: %s.%s: %w", typeGenerationErrors.ErrInvalidTag, t.ReflectType, field.Name, err),
+				)
+			}
+		}
+
 		postgresTag := tag.New(field.Tag.Get("postgres"))
 		if postgresTag != nil {
 			if postgresTag.Skip {
@@ -398,8 +563,10 @@ func (t *InterfaceDeclaration) String() (string, error) {
 	return strings.Join(slices.Concat([]string{table}, associativeTables, indices), "\n\n"), nil
 }
 
+// QualifiedName returns the table name, which for instantiations of generic types is derived from the type arguments,
+// e.g. "page_of_user" for `Page[User]`.
 func (t *InterfaceDeclaration) QualifiedName() string {
-	return toSnakeCase(t.Identifier)
+	return naming_convention.SnakeCase.Apply(t.c.InstantiationName(t.InterfaceDeclaration))
 }
 
 func (t *InterfaceDeclaration) TypeReference() *TypeReference {
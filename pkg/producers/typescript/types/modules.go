@@ -0,0 +1,317 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+type ModuleStrategy int
+
+const (
+	// ModulePerPackage renders the declarations of each Go package into one module.
+	ModulePerPackage ModuleStrategy = iota
+	// ModulePerType renders each declaration into its own module.
+	ModulePerType
+)
+
+const (
+	indexModulePath = "index"
+	// sharedModulePath is the module of declarations without a package, i.e. of anonymous structs and predeclared
+	// types.
+	sharedModulePath = "shared"
+)
+
+func declarationReflectType(typeDeclaration type_declaration.TypeDeclaration) reflect.Type {
+	switch v := typeDeclaration.(type) {
+	case *type_declaration.InterfaceDeclaration:
+		return v.ReflectType
+	case *type_declaration.TypeAliasDeclaration:
+		return v.ReflectType
+	case *type_declaration.UnionDeclaration:
+		return v.ReflectType
+	default:
+		return nil
+	}
+}
+
+// packageModulePaths maps package paths to module paths, which are the package paths relative to the longest
+// common parent directory of all the packages, e.g. "github.com/acme/api/users" -> "users".
+func packageModulePaths(pkgPaths []string) map[string]string {
+	var commonParent []string
+	for i, pkgPath := range pkgPaths {
+		components := strings.Split(pkgPath, "/")
+		parent := components[:len(components)-1]
+		if i == 0 {
+			commonParent = parent
+			continue
+		}
+
+		n := 0
+		for n < len(commonParent) && n < len(parent) && commonParent[n] == parent[n] {
+			n++
+		}
+		commonParent = commonParent[:n]
+	}
+
+	modulePaths := make(map[string]string, len(pkgPaths))
+	for _, pkgPath := range pkgPaths {
+		modulePaths[pkgPath] = strings.Join(strings.Split(pkgPath, "/")[len(commonParent):], "/")
+	}
+
+	return modulePaths
+}
+
+// relativeImportPath returns the path with which the module at `from` imports the module at `to`.
+func relativeImportPath(from string, to string) string {
+	fromDirectories := strings.Split(from, "/")
+	fromDirectories = fromDirectories[:len(fromDirectories)-1]
+	toComponents := strings.Split(to, "/")
+
+	n := 0
+	for n < len(fromDirectories) && n < len(toComponents)-1 && fromDirectories[n] == toComponents[n] {
+		n++
+	}
+
+	if n == len(fromDirectories) {
+		return "./" + strings.Join(toComponents[n:], "/")
+	}
+
+	return strings.Repeat("../", len(fromDirectories)-n) + strings.Join(toComponents[n:], "/")
+}
+
+func collectTypeReferences(t Type, references map[type_declaration.TypeDeclaration]struct{}) {
+	switch v := t.(type) {
+	case *TypeReference:
+		switch typeDeclaration := v.TypeDeclaration.(type) {
+		case *InterfaceDeclaration:
+			references[typeDeclaration.InterfaceDeclaration] = struct{}{}
+		case *TypeAliasDeclaration:
+			references[typeDeclaration.TypeAliasDeclaration] = struct{}{}
+		case *UnionDeclaration:
+			references[typeDeclaration.UnionDeclaration] = struct{}{}
+		}
+		for _, typeArgument := range v.TypeArguments {
+			collectTypeReferences(typeArgument, references)
+		}
+	case *ArrayType:
+		collectTypeReferences(v.ItemsType, references)
+	case *MapType:
+		collectTypeReferences(v.IndexType, references)
+		collectTypeReferences(v.ValueType, references)
+	case UnionType:
+		for _, unionMemberType := range v.Types {
+			collectTypeReferences(unionMemberType, references)
+		}
+	case *UnionType:
+		collectTypeReferences(*v, references)
+	}
+}
+
+// moduleDeclarations returns all declarations to be rendered into modules, in the order of the context.
+func (c *Context) moduleDeclarations() ([]type_declaration.TypeDeclaration, error) {
+	declarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return nil, fmt.Errorf("ordered type declarations: %w", err)
+	}
+	declarations = c.RemoveMappedTypeDeclarations(declarations, hasTypeMapping)
+
+	// Instantiations of a generic type are rendered as one generic declaration.
+	isInstantiationDuplicate := func(typeDeclaration type_declaration.TypeDeclaration) bool {
+		interfaceDeclaration, ok := typeDeclaration.(*type_declaration.InterfaceDeclaration)
+		return ok && c.GetGenericDeclaration(interfaceDeclaration) != interfaceDeclaration
+	}
+
+	return slices.DeleteFunc(declarations, isInstantiationDuplicate), nil
+}
+
+// renderDeclaration renders the declaration and returns the declarations that it references.
+func (c *Context) renderDeclaration(
+	typeDeclaration type_declaration.TypeDeclaration,
+) (string, map[type_declaration.TypeDeclaration]struct{}, error) {
+	references := map[type_declaration.TypeDeclaration]struct{}{}
+
+	switch v := typeDeclaration.(type) {
+	case *type_declaration.InterfaceDeclaration:
+		interfaceDeclaration := &InterfaceDeclaration{InterfaceDeclaration: v, c: c}
+		signatures, err := interfaceDeclaration.propertySignatures()
+		if err != nil {
+			return "", nil, fmt.Errorf("property signatures: %w", err)
+		}
+		for _, signature := range signatures {
+			collectTypeReferences(signature.Type, references)
+		}
+
+		d, err := interfaceDeclaration.String()
+		if err != nil {
+			return "", nil, motmedelErrors.New(fmt.Errorf("to type script: %w", err), interfaceDeclaration)
+		}
+
+		return d, references, nil
+	case *type_declaration.TypeAliasDeclaration:
+		typeAliasDeclaration := &TypeAliasDeclaration{TypeAliasDeclaration: v, c: c}
+		typeScriptType, err := c.getTypeScriptType(v.ReflectType, false)
+		if err != nil {
+			return "", nil, fmt.Errorf("get type script type: %w", err)
+		}
+		collectTypeReferences(typeScriptType, references)
+
+		d, err := typeAliasDeclaration.ToTypeScript()
+		if err != nil {
+			return "", nil, motmedelErrors.New(fmt.Errorf("to type script: %w", err), typeAliasDeclaration)
+		}
+
+		return d, references, nil
+	case *type_declaration.UnionDeclaration:
+		for _, variant := range v.Variants {
+			if variant != nil && variant.InterfaceDeclaration != nil {
+				references[variant.InterfaceDeclaration] = struct{}{}
+			}
+		}
+
+		unionDeclaration := &UnionDeclaration{UnionDeclaration: v, c: c}
+		d, err := unionDeclaration.String()
+		if err != nil {
+			return "", nil, motmedelErrors.New(fmt.Errorf("to type script: %w", err), unionDeclaration)
+		}
+
+		return d, references, nil
+	default:
+		return "", nil, nil
+	}
+}
+
+// RenderModules renders the declarations into modules according to the context's module strategy, with
+// `import type` statements for references across modules, and an "index.ts" barrel module re-exporting all modules.
+// The returned map maps slash-separated file paths to the modules' contents.
+func (c *Context) RenderModules() (map[string]string, error) {
+	declarations, err := c.moduleDeclarations()
+	if err != nil {
+		return nil, fmt.Errorf("module declarations: %w", err)
+	}
+
+	declarationToModulePath := make(map[type_declaration.TypeDeclaration]string, len(declarations))
+	switch c.ModuleStrategy {
+	case ModulePerType:
+		for _, typeDeclaration := range declarations {
+			// Module paths are compared case-insensitively, as are the file names of some file systems.
+			modulePath := typeDeclaration.QualifiedName()
+			if strings.EqualFold(modulePath, indexModulePath) {
+				return nil, motmedelErrors.NewWithTrace(
+					fmt.Errorf("%w: the module of %s is the index module", typeGenerationErrors.ErrNameCollision, modulePath),
+					typeDeclaration,
+				)
+			}
+			declarationToModulePath[typeDeclaration] = modulePath
+		}
+	default:
+		pkgPathSet := map[string]struct{}{}
+		for _, typeDeclaration := range declarations {
+			if reflectType := declarationReflectType(typeDeclaration); reflectType != nil && reflectType.PkgPath() != "" {
+				pkgPathSet[reflectType.PkgPath()] = struct{}{}
+			}
+		}
+		pkgPathToModulePath := packageModulePaths(slices.Sorted(maps.Keys(pkgPathSet)))
+		// The module of a package must not be the index module, nor the shared module, with which it would merge.
+		for pkgPath, modulePath := range pkgPathToModulePath {
+			if strings.EqualFold(modulePath, indexModulePath) || strings.EqualFold(modulePath, sharedModulePath) {
+				return nil, motmedelErrors.NewWithTrace(
+					fmt.Errorf(
+						"%w: the module of package %s is the %s module",
+						typeGenerationErrors.ErrNameCollision,
+						pkgPath,
+						modulePath,
+					),
+					pkgPath,
+				)
+			}
+		}
+
+		for _, typeDeclaration := range declarations {
+			modulePath := sharedModulePath
+			if reflectType := declarationReflectType(typeDeclaration); reflectType != nil && reflectType.PkgPath() != "" {
+				modulePath = pkgPathToModulePath[reflectType.PkgPath()]
+			}
+			declarationToModulePath[typeDeclaration] = modulePath
+		}
+	}
+
+	var modulePaths []string
+	modulePathToDeclarationStrings := map[string][]string{}
+	modulePathToImports := map[string]map[string]map[string]struct{}{}
+
+	for _, typeDeclaration := range declarations {
+		modulePath := declarationToModulePath[typeDeclaration]
+		if _, ok := modulePathToDeclarationStrings[modulePath]; !ok {
+			modulePaths = append(modulePaths, modulePath)
+			modulePathToImports[modulePath] = map[string]map[string]struct{}{}
+		}
+
+		d, references, err := c.renderDeclaration(typeDeclaration)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("render declaration: %w", err), typeDeclaration)
+		}
+		modulePathToDeclarationStrings[modulePath] = append(modulePathToDeclarationStrings[modulePath], d)
+
+		for reference := range references {
+			if interfaceDeclaration, ok := reference.(*type_declaration.InterfaceDeclaration); ok {
+				reference = c.GetGenericDeclaration(interfaceDeclaration)
+			}
+
+			referenceModulePath, ok := declarationToModulePath[reference]
+			if !ok || referenceModulePath == modulePath {
+				continue
+			}
+
+			importPath := relativeImportPath(modulePath, referenceModulePath)
+			if _, ok := modulePathToImports[modulePath][importPath]; !ok {
+				modulePathToImports[modulePath][importPath] = map[string]struct{}{}
+			}
+			modulePathToImports[modulePath][importPath][reference.QualifiedName()] = struct{}{}
+		}
+	}
+
+	files := make(map[string]string, len(modulePaths)+1)
+
+	for _, modulePath := range modulePaths {
+		var stringBuilder strings.Builder
+
+		imports := modulePathToImports[modulePath]
+		for _, importPath := range slices.Sorted(maps.Keys(imports)) {
+			stringBuilder.WriteString(
+				fmt.Sprintf(
+					"import type { %s } from '%s';\n",
+					strings.Join(slices.Sorted(maps.Keys(imports[importPath])), ", "),
+					importPath,
+				),
+			)
+		}
+		if len(imports) > 0 {
+			stringBuilder.WriteString("\n")
+		}
+
+		for i, d := range modulePathToDeclarationStrings[modulePath] {
+			if i > 0 {
+				stringBuilder.WriteString("\n")
+			}
+			stringBuilder.WriteString(d)
+			stringBuilder.WriteString("\n")
+		}
+
+		files[modulePath+".ts"] = stringBuilder.String()
+	}
+
+	var indexBuilder strings.Builder
+	for _, modulePath := range slices.Sorted(slices.Values(modulePaths)) {
+		indexBuilder.WriteString(fmt.Sprintf("export * from './%s';\n", modulePath))
+	}
+	files[indexModulePath+".ts"] = indexBuilder.String()
+
+	return files, nil
+}
@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+)
+
+// Tag is a `typescript` tag, a comma-separated list of options, e.g. `typescript:"readonly"`. Property names are those
+// of the `json` tag, as the declarations describe the values that json.Marshal() produces.
+type Tag struct {
+	Readonly     bool
+	OtherOptions []string
+}
+
+func New(tagString string) *Tag {
+	trimmedTagString := strings.TrimSpace(tagString)
+	if trimmedTagString == "" {
+		return nil
+	}
+
+	var tag Tag
+
+	for _, option := range strings.Split(trimmedTagString, ",") {
+		option = strings.TrimSpace(option)
+		switch strings.ToLower(option) {
+		case "":
+		case "readonly":
+			tag.Readonly = true
+		default:
+			tag.OtherOptions = append(tag.OtherOptions, option)
+		}
+	}
+
+	return &tag
+}
+
+// Validate reports the unknown options of the tag.
+func (t *Tag) Validate() error {
+	var errs []error
+
+	for _, option := range t.OtherOptions {
+		errs = append(
+			errs,
+			motmedelErrors.NewWithTrace(fmt.Errorf("%w: %s", typeGenerationErrors.ErrUnknownTagOption, option)),
+		)
+	}
+
+	return errors.Join(errs...)
+}
@@ -10,12 +10,14 @@ import (
 	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
 	"github.com/vphpersson/type_generation/pkg/types/shape"
 	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
 
 	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
 	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
 	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
 	"github.com/Motmedel/utils_go/pkg/utils"
 	jsonschemaTag "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+	typescriptTag "github.com/vphpersson/type_generation/pkg/producers/typescript/types/tag"
 )
 
 func isTime(t reflect.Type) bool {
@@ -56,14 +58,179 @@ func isPrimitiveAlias(reflectType reflect.Type) bool {
 	return isPrimitive(reflectType.Kind()) && reflectType.Name() != reflectType.Kind().String()
 }
 
+type DeclarationStyle int
+
+const (
+	// DeclarationStyleInterface renders struct declarations as `export interface X {...}`.
+	DeclarationStyleInterface DeclarationStyle = iota
+	// DeclarationStyleTypeAlias renders struct declarations as `export type X = {...};`.
+	DeclarationStyleTypeAlias
+)
+
+type MapStyle int
+
+const (
+	// MapStyleIndexSignature renders maps as `{ [key: K]: V }`.
+	MapStyleIndexSignature MapStyle = iota
+	// MapStyleRecord renders maps as `Record<K, V>`.
+	MapStyleRecord
+)
+
+type Int64Style int
+
+const (
+	// Int64StyleNumber renders 64-bit integers as `number`, which cannot represent all their values exactly.
+	Int64StyleNumber Int64Style = iota
+	// Int64StyleString renders 64-bit integers as `string`, for APIs that encode them as strings.
+	Int64StyleString
+	// Int64StyleBigInt renders 64-bit integers as `bigint`, for clients that parse them as such.
+	Int64StyleBigInt
+)
+
+// hasTypeMapping reports whether the type mapping has a TypeScript representation, in which case the mapped type is not
+// rendered as a declaration.
+func hasTypeMapping(typeMapping *type_mapping.TypeMapping) bool {
+	return typeMapping.TypeScript != ""
+}
+
 type Context struct {
 	*typeGenerationContext.Context
 	GenerateNominalTypes bool
+	// ModuleStrategy controls how RenderModules groups declarations into modules.
+	ModuleStrategy   ModuleStrategy
+	DeclarationStyle DeclarationStyle
+	MapStyle         MapStyle
+	// ReadonlyProperties marks all properties `readonly`. Individual properties can be marked with the `readonly`
+	// option of the `typescript` tag.
+	ReadonlyProperties bool
+	// ReadonlyCollections renders arrays as `ReadonlyArray<T>` and maps as readonly index signatures or
+	// `Readonly<Record<K, V>>`.
+	ReadonlyCollections bool
+	// Int64Style determines the type of 64-bit integers; `number` is imprecise beyond 2^53.
+	Int64Style Int64Style
+	// GenerateUnionMatchers renders a `match<Union>` function for each tagged union, which requires a handler for
+	// every variant and thus makes the handling exhaustive.
+	GenerateUnionMatchers bool
+	// StrictTags makes rendering fail if the `typescript` tags of fields have problems, as reported by LintTag.
+	StrictTags bool
+}
+
+// LintTag reports the problems of the `typescript` tag of a field, i.e. unknown options.
+func (c *Context) LintTag(field reflect.StructField) error {
+	typeScriptTag := typescriptTag.New(field.Tag.Get("typescript"))
+	if typeScriptTag == nil {
+		return nil
+	}
+
+	return typeScriptTag.Validate()
+}
+
+func (c *Context) newArrayType(itemsType Type) *ArrayType {
+	return &ArrayType{ItemsType: itemsType, Readonly: c.ReadonlyCollections}
+}
+
+func (c *Context) newMapType(indexType Type, valueType Type) *MapType {
+	return &MapType{
+		IndexType: indexType,
+		ValueType: valueType,
+		Record:    c.MapStyle == MapStyleRecord,
+		Readonly:  c.ReadonlyCollections,
+	}
+}
+
+// getIndexType returns the index signature parameter type of a map key type.
+func getIndexType(keyType reflect.Type) (Type, error) {
+	keyKind := keyType.Kind()
+	switch {
+	case keyKind == reflect.String:
+		return String, nil
+	case typeGenerationContext.GetMarshalerKind(keyType) == typeGenerationContext.MarshalerKindText:
+		return String, nil
+	case isNumber(keyKind):
+		return Number, nil
+	default:
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, keyKind),
+			keyKind,
+		)
+	}
+}
+
+// shapeProducer produces the TypeScript types of the parts of a shape.
+type shapeProducer struct {
+	*Context
+}
+
+func (p shapeProducer) Type(reflectType reflect.Type) (Type, error) {
+	return p.GetTypeScriptType(reflectType)
+}
+
+func (p shapeProducer) KeyType(reflectType reflect.Type) (Type, error) {
+	return getIndexType(reflectType)
+}
+
+func (p shapeProducer) TypeParameter(name string) Type {
+	return &TypeParameter{Identifier: name}
+}
+
+func (p shapeProducer) ArrayType(itemsType Type) Type {
+	return p.newArrayType(itemsType)
+}
+
+func (p shapeProducer) MapType(keyType Type, valueType Type) Type {
+	return p.newMapType(keyType, valueType)
+}
+
+func (p shapeProducer) TypeArguments(t Type) []Type {
+	if typeReference, ok := t.(*TypeReference); ok {
+		return typeReference.TypeArguments
+	}
+	return nil
+}
+
+// getShapeType returns the TypeScript type of a generic struct's field type, in which the parts using type
+// parameters are replaced by the parameters, e.g. `T[][]` for a `[][]T` field and `Wrapper<T>` for a `Wrapper[T]`
+// field.
+func (c *Context) getShapeType(typeShape *shape.Shape, reflectType reflect.Type) (Type, error) {
+	return shape.Resolve[Type](shapeProducer{c}, typeShape, reflectType)
 }
 
 func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
+	return c.getTypeScriptType(reflectType, true)
+}
+
+// getOverrideType returns the TypeScript type of types represented without regard to their structure, i.e. of
+// types with a type mapping and of types that customize their JSON encoding, or nil for other types.
+func (c *Context) getOverrideType(reflectType reflect.Type) Type {
+	if typeMapping := c.GetTypeMapping(reflectType); typeMapping != nil && typeMapping.TypeScript != "" {
+		return BasicType(typeMapping.TypeScript)
+	}
+
+	switch typeGenerationContext.GetMarshalerKind(reflectType) {
+	case typeGenerationContext.MarshalerKindText:
+		return String
+	case typeGenerationContext.MarshalerKindJSON:
+		return Any
+	default:
+		return nil
+	}
+}
+
+// getTypeScriptType returns the TypeScript type of the provided type. If useTypeAliases is false, a reference to the
+// type's own type alias declaration is not returned in place of its underlying type, which is needed to render the
+// type alias declaration itself.
+func (c *Context) getTypeScriptType(reflectType reflect.Type, useTypeAliases bool) (Type, error) {
 	reflectType = motmedelReflect.RemoveIndirection(reflectType)
 
+	if overrideType := c.getOverrideType(reflectType); overrideType != nil {
+		if useTypeAliases {
+			if typeAliasDeclaration, ok := c.TypeDeclarations[reflectType].(*type_declaration.TypeAliasDeclaration); ok {
+				return (&TypeAliasDeclaration{TypeAliasDeclaration: typeAliasDeclaration, c: c}).TypeReference(), nil
+			}
+		}
+		return overrideType, nil
+	}
+
 	var typeScriptType Type
 	switch kind := reflectType.Kind(); kind {
 	case reflect.Struct:
@@ -85,44 +252,16 @@ func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
 			if genericTypeInfo := interfaceDeclaration.GenericTypeInfo; genericTypeInfo != nil {
 				var typeArguments []Type
 				for _, typeParameterName := range genericTypeInfo.TypeParameterNames {
-					// Find a field in the generic struct that uses the generic type parameter.
-
-					typeParameterNameToFieldName := genericTypeInfo.TypeParameterNameToFieldName
-					fieldName, err := utils.MapGet(typeParameterNameToFieldName, typeParameterName)
-					if err != nil {
-						return nil, motmedelErrors.New(
-							fmt.Errorf("map get: %w", err),
-							typeParameterNameToFieldName, typeParameterName,
-						)
-					}
+					// Determine the concrete type for this instantiation from a field that uses the generic type parameter.
 
-					field, ok := reflectType.FieldByName(fieldName)
+					argReflectType, ok := c.TypeArgument(reflectType, typeParameterName)
 					if !ok {
 						return nil, motmedelErrors.NewWithTrace(
 							typeGenerationErrors.ErrNoStructField,
-							reflectType, fieldName,
+							reflectType, typeParameterName,
 						)
 					}
 
-					// Determine the "shape" of the field that uses the generic type parameter and extract the concrete type
-					// for this instantiation.
-
-					argReflectType := field.Type
-					if fieldShape, ok := genericTypeInfo.FieldNameToShape[fieldName]; ok {
-						switch fieldShape.Kind {
-						case shape.KindPointer:
-							argReflectType = motmedelReflect.RemoveIndirection(argReflectType)
-						case shape.KindSlice, shape.KindArray:
-							argReflectType = argReflectType.Elem()
-						case shape.KindMapValue:
-							argReflectType = argReflectType.Elem()
-						case shape.KindMapKey:
-							argReflectType = argReflectType.Key()
-						case shape.KindDirect:
-							// use as-is
-						}
-					}
-
 					typeArgument, err := c.GetTypeScriptType(argReflectType)
 					if err != nil {
 						return nil, fmt.Errorf("get type script type: %w", err)
@@ -150,6 +289,16 @@ func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
 		reflect.Float32,
 		reflect.Float64:
 		typeScriptType = Number
+
+		if kind != reflect.Float32 && kind != reflect.Float64 && reflectType.Bits() == 64 {
+			switch c.Int64Style {
+			case Int64StyleString:
+				typeScriptType = String
+			case Int64StyleBigInt:
+				typeScriptType = BigInt
+			default:
+			}
+		}
 	case reflect.String:
 		typeScriptType = String
 	case reflect.Bool:
@@ -168,17 +317,9 @@ func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
 		// "string" or "number" directly.
 		//
 		// [1] https://www.typescriptlang.org/docs/handbook/advanced-types.html#index-types-and-index-signatures.
-		var indexType Type
-		reflectTypeKeyKind := reflectType.Key().Kind()
-		if reflectTypeKeyKind == reflect.String {
-			indexType = String
-		} else if isNumber(reflectTypeKeyKind) {
-			indexType = Number
-		} else {
-			return nil, motmedelErrors.NewWithTrace(
-				fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, reflectTypeKeyKind),
-				reflectTypeKeyKind,
-			)
+		indexType, err := getIndexType(reflectType.Key())
+		if err != nil {
+			return nil, err
 		}
 
 		valueType, err := c.GetTypeScriptType(reflectType.Elem())
@@ -186,21 +327,24 @@ func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
 			return nil, err
 		}
 
-		typeScriptType = &MapType{IndexType: indexType, ValueType: valueType}
+		typeScriptType = c.newMapType(indexType, valueType)
 	case reflect.Slice, reflect.Array:
 		itemsType, err := c.GetTypeScriptType(reflectType.Elem())
 		if err != nil {
 			return nil, err
 		}
-		typeScriptType = &ArrayType{ItemsType: itemsType}
+		typeScriptType = c.newArrayType(itemsType)
 
 	case reflect.Interface:
+		if unionDeclaration := c.GetUnionDeclaration(reflectType); unionDeclaration != nil {
+			return (&UnionDeclaration{UnionDeclaration: unionDeclaration, c: c}).TypeReference(), nil
+		}
 		typeScriptType = Any
 	default:
 		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %T", typeGenerationErrors.ErrUnsupportedKind, kind), kind)
 	}
 
-	useTypeAlias := reflectType.Name() != "" &&
+	useTypeAlias := useTypeAliases && reflectType.Name() != "" &&
 		(!isPrimitive(reflectType.Kind()) || isPrimitiveAlias(reflectType)) &&
 		!isTime(reflectType)
 
@@ -222,52 +366,47 @@ func (c *Context) GetTypeScriptType(reflectType reflect.Type) (Type, error) {
 }
 
 func (c *Context) Render() (string, error) {
-	var interfaceDeclarations []*InterfaceDeclaration
-	var typeAliasDeclarations []*TypeAliasDeclaration
+	typeDeclarations, err := c.OrderedTypeDeclarations()
+	if err != nil {
+		return "", fmt.Errorf("ordered type declarations: %w", err)
+	}
+	typeDeclarations = c.RemoveMappedTypeDeclarations(typeDeclarations, hasTypeMapping)
 
-	for _, typeDeclaration := range c.TypeDeclarationsInOrder {
+	var declarations []string
+
+	for _, typeDeclaration := range typeDeclarations {
+		var d string
 		switch v := any(typeDeclaration).(type) {
 		case *type_declaration.InterfaceDeclaration:
-			interfaceDeclarations = append(
-				interfaceDeclarations,
-				&InterfaceDeclaration{InterfaceDeclaration: v, c: c},
-			)
+			// Instantiations of a generic type are rendered as one generic declaration.
+			if c.GetGenericDeclaration(v) != v {
+				continue
+			}
+			interfaceDeclaration := &InterfaceDeclaration{InterfaceDeclaration: v, c: c}
+			d, err = interfaceDeclaration.String()
+			if err != nil {
+				return "", motmedelErrors.New(fmt.Errorf("to type script: %w", err), interfaceDeclaration)
+			}
+		case *type_declaration.UnionDeclaration:
+			unionDeclaration := &UnionDeclaration{UnionDeclaration: v, c: c}
+			d, err = unionDeclaration.String()
+			if err != nil {
+				return "", motmedelErrors.New(fmt.Errorf("to type script: %w", err), unionDeclaration)
+			}
 		case *type_declaration.TypeAliasDeclaration:
-			typeAliasDeclarations = append(
-				typeAliasDeclarations,
-				&TypeAliasDeclaration{TypeAliasDeclaration: v, c: c},
-			)
-		}
-	}
-
-	var stringBuilder strings.Builder
-
-	for i, interfaceDeclaration := range interfaceDeclarations {
-		if i > 0 {
-			stringBuilder.WriteString("\n")
-		}
-		d, err := interfaceDeclaration.String()
-		if err != nil {
-			return "", motmedelErrors.New(fmt.Errorf("to type script: %w", err), interfaceDeclaration)
-		}
-		stringBuilder.WriteString(d)
-		stringBuilder.WriteString("\n")
-	}
-
-	for _, typeAliasDeclaration := range typeAliasDeclarations {
-		if len(interfaceDeclarations) > 0 {
-			stringBuilder.WriteString("\n")
+			typeAliasDeclaration := &TypeAliasDeclaration{TypeAliasDeclaration: v, c: c}
+			d, err = typeAliasDeclaration.ToTypeScript()
+			if err != nil {
+				return "", motmedelErrors.New(fmt.Errorf("to type script: %w", err), typeAliasDeclaration)
+			}
+		default:
+			continue
 		}
 
-		d, err := typeAliasDeclaration.ToTypeScript()
-		if err != nil {
-			return "", motmedelErrors.New(fmt.Errorf("to type script: %w", err), typeAliasDeclaration)
-		}
-		stringBuilder.WriteString(d)
-		stringBuilder.WriteString("\n")
+		declarations = append(declarations, d+"\n")
 	}
 
-	return stringBuilder.String(), nil
+	return strings.Join(declarations, "\n"), nil
 }
 
 func renderTypeParams(params []string) string {
@@ -282,14 +421,19 @@ type InterfaceDeclaration struct {
 	c *Context
 }
 
-func (t *InterfaceDeclaration) String() (string, error) {
-	var propertyStrings []string
+// propertySignature is a property of an interface declaration as rendered in TypeScript.
+type propertySignature struct {
+	Identifier string
+	Optional   bool
+	Readonly   bool
+	Type       Type
+}
+
+// propertySignatures returns the TypeScript properties of the interface declaration, excluding skipped fields.
+func (t *InterfaceDeclaration) propertySignatures() ([]*propertySignature, error) {
+	var signatures []*propertySignature
 
-	var typeParameters []string
 	genericTypeInfo := t.GenericTypeInfo
-	if genericTypeInfo != nil {
-		typeParameters = genericTypeInfo.TypeParameterNames
-	}
 
 	for _, property := range t.Properties {
 		if property == nil {
@@ -298,18 +442,31 @@ func (t *InterfaceDeclaration) String() (string, error) {
 
 		field := property.Field
 		if field == nil {
-			return "", motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
+			return nil, motmedelErrors.NewWithTrace(nil_error.New("property field"), property)
 		}
 
-		identifier := property.Identifier
+		identifier := t.c.PropertyNameConvention.Apply(property.Identifier)
 		optional := property.Optional
+		readonly := t.c.ReadonlyProperties
 
 		fieldTag := field.Tag
 
+		if t.c.StrictTags {
+			if err := t.c.LintTag(*field); err != nil {
+				return nil, motmedelErrors.NewWithTrace(
+					fmt.Errorf("%w: %s.%s: %w", typeGenerationErrors.ErrInvalidTag, t.ReflectType, field.Name, err),
+				)
+			}
+		}
+
+		if typeScriptTag := typescriptTag.New(fieldTag.Get("typescript")); typeScriptTag != nil {
+			readonly = readonly || typeScriptTag.Readonly
+		}
+
 		rawSchemaTag := fieldTag.Get("jsonschema")
 		schemaTag, err := jsonschemaTag.New(rawSchemaTag)
 		if err != nil {
-			return "", motmedelErrors.New(fmt.Errorf("jsonschema tag new: %w", err), schemaTag)
+			return nil, motmedelErrors.New(fmt.Errorf("jsonschema tag new: %w", err), schemaTag)
 		}
 		if schemaTag != nil {
 			if schemaTag.Skip {
@@ -335,56 +492,82 @@ func (t *InterfaceDeclaration) String() (string, error) {
 			}
 		}
 
-		optionalString := ""
-		if optional {
-			optionalString = "?"
-		}
-
 		typeScriptType, err := t.c.GetTypeScriptType(field.Type)
 		if err != nil {
-			return "", fmt.Errorf("get type script type: %w", err)
+			return nil, fmt.Errorf("get type script type: %w", err)
 		}
 
 		// Replace the field's type with the generic type parameter if the field uses the generic type parameter.
 
 		if genericTypeInfo != nil {
 			if fieldShape, ok := genericTypeInfo.FieldNameToShape[property.Identifier]; ok {
-				switch fieldShape.Kind {
-				case shape.KindDirect, shape.KindPointer:
-					typeScriptType = &TypeParameter{Identifier: fieldShape.Param}
-				case shape.KindSlice, shape.KindArray:
-					typeScriptType = &ArrayType{ItemsType: &TypeParameter{Identifier: fieldShape.Param}}
-				case shape.KindMapValue:
-					// Preserve the discovered key type from the reflect-populated MapType, replace value with param.
-					mapType, err := utils.ConvertToNonZero[*MapType](typeScriptType)
-					if err != nil {
-						return "", fmt.Errorf("convert to non zero: %w", err)
-					}
-					mapType.ValueType = &TypeParameter{Identifier: fieldShape.Param}
-				case shape.KindMapKey:
-					// If the key is parameterized, ensure index is the parameter and keep value as-is.
-					mapType, err := utils.ConvertToNonZero[*MapType](typeScriptType)
-					if err != nil {
-						return "", fmt.Errorf("convert to non zero: %w", err)
-					}
-					mapType.IndexType = &TypeParameter{Identifier: fieldShape.Param}
+				typeScriptType, err = t.c.getShapeType(fieldShape, field.Type)
+				if err != nil {
+					return nil, fmt.Errorf("get shape type: %w", err)
 				}
 			}
 		}
 
-		typeString, err := typeScriptType.String()
+		// Values of fields with the `string` option of the `json` tag are encoded inside JSON strings.
+		if property.Quoted {
+			typeScriptType = String
+		}
+
+		// `any` already includes `null`.
+		if property.Nullable && typeScriptType != Any {
+			typeScriptType = &UnionType{Types: []Type{typeScriptType, Null}}
+		}
+
+		signatures = append(
+			signatures,
+			&propertySignature{Identifier: identifier, Optional: optional, Readonly: readonly, Type: typeScriptType},
+		)
+	}
+
+	return signatures, nil
+}
+
+func (t *InterfaceDeclaration) String() (string, error) {
+	var typeParameters []string
+	if genericTypeInfo := t.GenericTypeInfo; genericTypeInfo != nil {
+		typeParameters = genericTypeInfo.TypeParameterNames
+	}
+
+	signatures, err := t.propertySignatures()
+	if err != nil {
+		return "", fmt.Errorf("property signatures: %w", err)
+	}
+
+	var propertyStrings []string
+	for _, signature := range signatures {
+		optionalString := ""
+		if signature.Optional {
+			optionalString = "?"
+		}
+
+		readonlyString := ""
+		if signature.Readonly {
+			readonlyString = "readonly "
+		}
+
+		typeString, err := signature.Type.String()
 		if err != nil {
 			return "", fmt.Errorf("type string: %w", err)
 		}
 
 		propertyStrings = append(
 			propertyStrings,
-			fmt.Sprintf("\t%s%s: %s;\n", identifier, optionalString, typeString),
+			fmt.Sprintf("\t%s%s%s: %s;\n", readonlyString, signature.Identifier, optionalString, typeString),
 		)
 	}
 
+	format := "export interface %s%s {\n%s}"
+	if t.c.DeclarationStyle == DeclarationStyleTypeAlias {
+		format = "export type %s%s = {\n%s};"
+	}
+
 	return fmt.Sprintf(
-		"export interface %s%s {\n%s}",
+		format,
 		t.Identifier,
 		renderTypeParams(typeParameters),
 		strings.Join(propertyStrings, ""),
@@ -415,7 +598,7 @@ func (a *TypeAliasDeclaration) QualifiedName() string {
 func (a *TypeAliasDeclaration) ToTypeScript() (string, error) {
 	params := renderTypeParams(a.TypeParameters)
 
-	typeScriptType, err := a.c.GetTypeScriptType(a.ReflectType)
+	typeScriptType, err := a.c.getTypeScriptType(a.ReflectType, false)
 	if err != nil {
 		return "", fmt.Errorf("get type script type: %w", err)
 	}
@@ -453,3 +636,68 @@ func (a *TypeAliasDeclaration) ToTypeScript() (string, error) {
 
 	return fmt.Sprintf("export type %s%s = %s;", a.Identifier, params, param), nil
 }
+
+var stringLiteralReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+func stringLiteral(s string) string {
+	return "'" + stringLiteralReplacer.Replace(s) + "'"
+}
+
+type UnionDeclaration struct {
+	*type_declaration.UnionDeclaration
+	c *Context
+}
+
+func (u *UnionDeclaration) TypeReference() *TypeReference {
+	return &TypeReference{TypeDeclaration: u}
+}
+
+func (u *UnionDeclaration) QualifiedName() string {
+	return u.Identifier
+}
+
+// String renders the union as a discriminated union of the variants, narrowing each variant's discriminator
+// property to its tag. The discriminator is quoted, as it need not be a valid identifier, e.g.:
+//
+//	export type Event =
+//		| (Created & { 'kind': 'created' })
+//		| (Deleted & { 'kind': 'deleted' });
+func (u *UnionDeclaration) String() (string, error) {
+	var stringBuilder strings.Builder
+
+	stringBuilder.WriteString(fmt.Sprintf("export type %s =", u.Identifier))
+	for _, variant := range u.Variants {
+		if variant == nil || variant.InterfaceDeclaration == nil {
+			return "", motmedelErrors.NewWithTrace(nil_error.New("union variant interface declaration"), u)
+		}
+
+		stringBuilder.WriteString(
+			fmt.Sprintf(
+				"\n\t| (%s & { %s: %s })",
+				variant.InterfaceDeclaration.QualifiedName(),
+				stringLiteral(u.Discriminator),
+				stringLiteral(variant.Tag),
+			),
+		)
+	}
+	stringBuilder.WriteString(";")
+
+	if u.c.GenerateUnionMatchers {
+		stringBuilder.WriteString(
+			fmt.Sprintf(
+				`
+
+export function match%[1]s<R>(
+	value: %[1]s,
+	handlers: { [K in %[1]s[%[2]s]]: (value: Extract<%[1]s, { %[2]s: K }>) => R },
+): R {
+	return (handlers[value[%[2]s]] as (value: %[1]s) => R)(value);
+}`,
+				u.Identifier,
+				stringLiteral(u.Discriminator),
+			),
+		)
+	}
+
+	return stringBuilder.String(), nil
+}
@@ -54,6 +54,7 @@ const (
 	String  = BasicType("string")
 	Null    = BasicType("null")
 	Any     = BasicType("any")
+	BigInt  = BasicType("bigint")
 )
 
 func (b BasicType) String() (string, error) { return string(b), nil }
@@ -77,6 +78,9 @@ func (u UnionType) String() (string, error) {
 type MapType struct {
 	IndexType Type
 	ValueType Type
+	// Record renders the map as `Record<K, V>` instead of as an index signature.
+	Record   bool
+	Readonly bool
 }
 
 func (m *MapType) String() (string, error) {
@@ -94,16 +98,30 @@ func (m *MapType) String() (string, error) {
 		return "", fmt.Errorf("value type string: %w", err)
 	}
 
+	if m.Record {
+		if m.Readonly {
+			return fmt.Sprintf("Readonly<Record<%s, %s>>", indexTypeString, valueTypeString), nil
+		}
+		return fmt.Sprintf("Record<%s, %s>", indexTypeString, valueTypeString), nil
+	}
+
+	if m.Readonly {
+		return fmt.Sprintf("{ readonly [key: %s]: %s }", indexTypeString, valueTypeString), nil
+	}
+
 	return fmt.Sprintf("{ [key: %s]: %s }", indexTypeString, valueTypeString), nil
 }
 
 type ArrayType struct {
 	ItemsType Type
+	Readonly  bool
 }
 
 func (a *ArrayType) String() (string, error) {
 	fmtStr := "%s[]"
-	if _, ok := a.ItemsType.(*UnionType); ok {
+	if a.Readonly {
+		fmtStr = "ReadonlyArray<%s>"
+	} else if _, ok := a.ItemsType.(*UnionType); ok {
 		fmtStr = "(%s)[]"
 	}
 
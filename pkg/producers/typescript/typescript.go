@@ -21,3 +21,17 @@ func Convert(values ...any) (string, error) {
 
 	return output, nil
 }
+
+func ConvertModules(moduleStrategy types.ModuleStrategy, values ...any) (map[string]string, error) {
+	tsContext := types.Context{Context: typeGenerationTypesContext.New(), ModuleStrategy: moduleStrategy}
+	if err := tsContext.Add(values...); err != nil {
+		return nil, fmt.Errorf("add: %w", err)
+	}
+
+	files, err := tsContext.RenderModules()
+	if err != nil {
+		return nil, motmedelErrors.New(fmt.Errorf("render modules: %w", err), tsContext)
+	}
+
+	return files, nil
+}
@@ -8,12 +8,15 @@ import (
 	"github.com/Motmedel/utils_go/pkg/errors/types/nil_error"
 	"github.com/vphpersson/type_generation/internal/generic_type_info"
 	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
 	"github.com/vphpersson/type_generation/pkg/types/shape"
 	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
 	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
 )
 
@@ -52,6 +55,33 @@ func isPrimitiveAlias(reflectType reflect.Type) bool {
 	return isPrimitive(reflectType.Kind()) && reflectType.Name() != reflectType.Kind().String()
 }
 
+// IsQuoted reports whether json.Marshal() encodes the field's value inside a JSON string because of the `string`
+// option, which only applies to boolean, number and string fields, or unnamed pointers to such.
+func IsQuoted(field reflect.StructField) bool {
+	jsonTag := motmedelJsonTag.New(field.Tag.Get("json"))
+	if jsonTag == nil || !jsonTag.String {
+		return false
+	}
+
+	fieldType := field.Type
+	if fieldType.Name() == "" && fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	return isPrimitive(fieldType.Kind()) && fieldType.Kind() != reflect.Uintptr
+}
+
+// innermostElem returns the type of the innermost elements of nested pointer, slice, array and map types.
+func innermostElem(reflectType reflect.Type) reflect.Type {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+	switch reflectType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return innermostElem(reflectType.Elem())
+	default:
+		return reflectType
+	}
+}
+
 type optionalFieldPolicy int
 
 const (
@@ -62,26 +92,57 @@ const (
 type Context struct {
 	TypeDeclarations        map[reflect.Type]type_declaration.TypeDeclaration
 	TypeDeclarationsInOrder []type_declaration.TypeDeclaration
-
-	usedQualifiedNames map[string]struct{}
-	anonymousCount     int
+	// TypeMappings overrides the representation of types in this context, taking precedence over mappings
+	// registered with RegisterTypeMapping.
+	TypeMappings map[reflect.Type]*type_mapping.TypeMapping
+	// Ordering determines the order in which producers render declarations.
+	Ordering Ordering
+
+	// NamingStrategy determines how types that share a name are named.
+	NamingStrategy NamingStrategy
+	// TypeNameConvention transforms the names of types, except for names provided by TypeName methods.
+	TypeNameConvention naming_convention.NamingConvention
+	// PropertyNameConvention transforms the names of fields, except for names provided by tags. As json.Marshal()
+	// encodes fields without a json tag by their Go names, the convention should be used only in combination with
+	// such tags, or for names that are not JSON property names, e.g. Postgres column names.
+	PropertyNameConvention naming_convention.NamingConvention
+
+	// usedQualifiedNames maps the identifiers in use to the types declared with them, if any.
+	usedQualifiedNames map[string]reflect.Type
+	instantiationNames map[reflect.Type]string
+	// genericDeclarations maps generic types, by package path and name, to the declarations of their first
+	// instantiations.
+	genericDeclarations map[string]*type_declaration.InterfaceDeclaration
+	anonymousCount      int
 }
 
-func (g *Context) makeUniqueIdentifier(base string) string {
-	id := base
-	i := 2
-	for {
-		if _, exists := g.usedQualifiedNames[id]; !exists {
-			return id
-		}
-		id = fmt.Sprintf("%s%d", base, i)
-		i++
+// getOrCreateTypeAliasDeclaration declares a type alias for the type, unless it is already declared or it is an
+// unnamed type, a predeclared type or the time type.
+func (g *Context) getOrCreateTypeAliasDeclaration(reflectType reflect.Type) error {
+	useTypeAlias := reflectType.Name() != "" &&
+		(!isPrimitive(reflectType.Kind()) || isPrimitiveAlias(reflectType)) &&
+		!isTime(reflectType)
+	if !useTypeAlias {
+		return nil
 	}
-}
 
-func (g *Context) makeUniqueAnonymousIdentifier() string {
-	g.anonymousCount++
-	return fmt.Sprintf("Anonymous%d", g.anonymousCount)
+	if _, ok := g.TypeDeclarations[reflectType]; ok {
+		return nil
+	}
+
+	identifier, err := g.declareIdentifier(reflectType)
+	if err != nil {
+		return fmt.Errorf("declare identifier: %w", err)
+	}
+
+	typeDeclaration := &type_declaration.TypeAliasDeclaration{
+		Identifier:  identifier,
+		ReflectType: reflectType,
+	}
+	g.TypeDeclarations[reflectType] = typeDeclaration
+	g.TypeDeclarationsInOrder = append(g.TypeDeclarationsInOrder, typeDeclaration)
+
+	return nil
 }
 
 func (g *Context) populateProperties(
@@ -151,33 +212,41 @@ func (g *Context) populateProperties(
 		default:
 		}
 
-		useTypeAlias := directType.Name() != "" && (!isPrimitive(directType.Kind()) || isPrimitiveAlias(directType))
-
-		if useTypeAlias {
-			if _, ok := g.TypeDeclarations[directType]; !ok {
-				typeName, _ := motmedelReflect.GetTypeName(directType)
-				identifier := caser.String(typeName)
-				if identifier == "" {
-					identifier = g.makeUniqueAnonymousIdentifier()
-				}
-
-				uniqueInterfaceName := g.makeUniqueIdentifier(identifier)
-				g.usedQualifiedNames[uniqueInterfaceName] = struct{}{}
+		if err := g.getOrCreateTypeAliasDeclaration(directType); err != nil {
+			return motmedelErrors.New(fmt.Errorf("get or create type alias declaration: %w", err), directType)
+		}
+		// Named element types are referenced by their aliases too, e.g. `Status[]`.
+		if elemType := innermostElem(directType); elemType != directType && elemType.Kind() != reflect.Struct {
+			if err := g.getOrCreateTypeAliasDeclaration(elemType); err != nil {
+				return motmedelErrors.New(fmt.Errorf("get or create type alias declaration: %w", err), elemType)
+			}
+		}
 
-				typeDeclaration := &type_declaration.TypeAliasDeclaration{
-					Identifier:  identifier,
-					ReflectType: directType,
-				}
-				g.TypeDeclarations[directType] = typeDeclaration
+		fieldKind := field.Type.Kind()
+		// The nullability of a field whose type is a type parameter depends on the type argument, and is thus not a
+		// property of the generic declaration.
+		if genericTypeInfo := interfaceDeclaration.GenericTypeInfo; genericTypeInfo != nil {
+			if fieldShape, ok := genericTypeInfo.FieldNameToShape[field.Name]; ok && fieldShape.Kind == shape.KindParam {
+				fieldKind = reflect.Invalid
 			}
 		}
 
+		nullable := false
+		switch fieldKind {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			jsonTag := motmedelJsonTag.New(field.Tag.Get("json"))
+			nullable = jsonTag == nil || !(jsonTag.OmitEmpty || jsonTag.OmitZero)
+		default:
+		}
+
 		interfaceDeclaration.Properties = append(
 			interfaceDeclaration.Properties,
 			&type_declaration.PropertySignature{
 				Identifier: propertyName,
 				Field:      &field,
 				Optional:   optionalFieldPolicy == forceOptional,
+				Nullable:   nullable,
+				Quoted:     IsQuoted(field),
 			},
 		)
 	}
@@ -206,7 +275,7 @@ func (g *Context) GetOrCreateInterfaceDeclaration(structType reflect.Type) (*typ
 		return nil, motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, structTypeKind)
 	}
 
-	if isTime(structType) {
+	if isTime(structType) || g.isOpaque(structType) {
 		return nil, nil
 	}
 
@@ -215,18 +284,33 @@ func (g *Context) GetOrCreateInterfaceDeclaration(structType reflect.Type) (*typ
 	}
 
 	typeName, isGenericType := motmedelReflect.GetTypeName(structType)
-	interfaceName := caser.String(typeName)
-	if interfaceName == "" {
-		interfaceName = g.makeUniqueAnonymousIdentifier()
-	}
 
-	uniqueInterfaceName := g.makeUniqueIdentifier(interfaceName)
-	g.usedQualifiedNames[uniqueInterfaceName] = struct{}{}
+	// All instantiations of a generic type share the identifier of the generic declaration.
+	genericKey := structType.PkgPath() + "." + typeName
+	genericDeclaration := g.genericDeclarations[genericKey]
 
-	interfaceDeclaration := &type_declaration.InterfaceDeclaration{Identifier: uniqueInterfaceName}
+	var uniqueInterfaceName string
+	if isGenericType && genericDeclaration != nil {
+		uniqueInterfaceName = genericDeclaration.Identifier
+	} else {
+		var err error
+		uniqueInterfaceName, err = g.declareIdentifier(structType)
+		if err != nil {
+			return nil, fmt.Errorf("declare identifier: %w", err)
+		}
+	}
+
+	interfaceDeclaration := &type_declaration.InterfaceDeclaration{
+		Identifier:  uniqueInterfaceName,
+		ReflectType: structType,
+	}
 	g.TypeDeclarations[structType] = interfaceDeclaration
 
 	if isGenericType {
+		if genericDeclaration == nil {
+			g.genericDeclarations[genericKey] = interfaceDeclaration
+		}
+
 		// Use AST to discover generic type info.
 
 		genericTypeInfo, err := generic_type_info.GetGenericTypeInfo(structType)
@@ -240,33 +324,14 @@ func (g *Context) GetOrCreateInterfaceDeclaration(structType reflect.Type) (*typ
 		interfaceDeclaration.GenericTypeInfo = genericTypeInfo
 
 		for _, typeParameterName := range genericTypeInfo.TypeParameterNames {
-			fieldName := genericTypeInfo.TypeParameterNameToFieldName[typeParameterName]
-			if fieldName == "" {
-				continue
-			}
-
-			field, ok := structType.FieldByName(fieldName)
+			argReflectType, ok := g.TypeArgument(structType, typeParameterName)
 			if !ok {
 				continue
 			}
 
-			argReflectType := field.Type
-			if fieldShape, ok := genericTypeInfo.FieldNameToShape[fieldName]; ok {
-				switch fieldShape.Kind {
-				case shape.KindPointer:
-					argReflectType = motmedelReflect.RemoveIndirection(argReflectType)
-				case shape.KindSlice, shape.KindArray:
-					argReflectType = argReflectType.Elem()
-				case shape.KindMapValue:
-					argReflectType = argReflectType.Elem()
-				case shape.KindMapKey:
-					argReflectType = argReflectType.Key()
-				case shape.KindDirect:
-					// use as-is
-				}
-			}
-
-			if directType := motmedelReflect.RemoveIndirection(argReflectType); directType.Kind() == reflect.Struct {
+			// Type arguments are referenced by the instantiation's type, e.g. `Page<User[]>`, so the structs within
+			// them need declarations even if no field uses them directly.
+			if directType := innermostElem(argReflectType); directType.Kind() == reflect.Struct {
 				if _, err = g.GetOrCreateInterfaceDeclaration(directType); err != nil {
 					return nil, motmedelErrors.New(
 						fmt.Errorf("get or create interface declaration: %w", err),
@@ -324,6 +389,28 @@ func New() *Context {
 	return &Context{
 		TypeDeclarations:        map[reflect.Type]type_declaration.TypeDeclaration{},
 		TypeDeclarationsInOrder: []type_declaration.TypeDeclaration{},
-		usedQualifiedNames:      map[string]struct{}{},
+		TypeMappings:            map[reflect.Type]*type_mapping.TypeMapping{},
+		usedQualifiedNames:      map[string]reflect.Type{},
+		instantiationNames:      map[reflect.Type]string{},
+		genericDeclarations:     map[string]*type_declaration.InterfaceDeclaration{},
+	}
+}
+
+// GetGenericDeclaration returns the declaration that represents all instantiations of the interface declaration's
+// generic type, i.e. the declaration of the first instantiation, or the interface declaration itself if its type is
+// not generic. Producers rendering generic declarations render only these.
+func (g *Context) GetGenericDeclaration(
+	interfaceDeclaration *type_declaration.InterfaceDeclaration,
+) *type_declaration.InterfaceDeclaration {
+	if interfaceDeclaration.GenericTypeInfo == nil {
+		return interfaceDeclaration
 	}
+
+	structType := interfaceDeclaration.ReflectType
+	typeName, _ := motmedelReflect.GetTypeName(structType)
+	if genericDeclaration, ok := g.genericDeclarations[structType.PkgPath()+"."+typeName]; ok {
+		return genericDeclaration
+	}
+
+	return interfaceDeclaration
 }
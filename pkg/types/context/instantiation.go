@@ -0,0 +1,74 @@
+package context
+
+import (
+	"reflect"
+
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// typeArgumentName returns the name with which a type argument is described in an instantiation name.
+func (g *Context) typeArgumentName(reflectType reflect.Type) string {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+
+	switch typeDeclaration := g.TypeDeclarations[reflectType].(type) {
+	case *type_declaration.InterfaceDeclaration:
+		return g.InstantiationName(typeDeclaration)
+	case nil:
+	default:
+		return typeDeclaration.QualifiedName()
+	}
+
+	if typeName, _ := motmedelReflect.GetTypeName(reflectType); typeName != "" {
+		return caser.String(typeName)
+	}
+
+	switch kind := reflectType.Kind(); kind {
+	case reflect.Slice, reflect.Array:
+		return g.typeArgumentName(reflectType.Elem()) + "List"
+	case reflect.Map:
+		return g.typeArgumentName(reflectType.Key()) + "To" + g.typeArgumentName(reflectType.Elem()) + "Map"
+	case reflect.Interface:
+		return "Any"
+	default:
+		return caser.String(kind.String())
+	}
+}
+
+// InstantiationName returns a readable name for a declaration that is unique across all instantiations of a generic
+// struct type, composed of the generic type's name and the type arguments, e.g. "PageOfUser" for `Page[User]` and
+// "PairOfStringAndUser" for `Pair[string, User]`. For declarations of non-generic types, the identifier is returned.
+//
+// Producers without a notion of generics use the name to render each instantiation as a separate definition.
+func (g *Context) InstantiationName(interfaceDeclaration *type_declaration.InterfaceDeclaration) string {
+	genericTypeInfo := interfaceDeclaration.GenericTypeInfo
+	if genericTypeInfo == nil {
+		return interfaceDeclaration.Identifier
+	}
+
+	if name, ok := g.instantiationNames[interfaceDeclaration.ReflectType]; ok {
+		return name
+	}
+
+	names := []string{interfaceDeclaration.Identifier, "Of"}
+	for i, typeParameterName := range genericTypeInfo.TypeParameterNames {
+		if i > 0 {
+			names = append(names, "And")
+		}
+
+		argReflectType, ok := g.TypeArgument(interfaceDeclaration.ReflectType, typeParameterName)
+		if !ok {
+			// The type parameter is not used by any field, thus the type argument cannot be determined.
+			names = append(names, typeParameterName)
+			continue
+		}
+		names = append(names, g.typeArgumentName(argReflectType))
+	}
+
+	// Instantiation names are reserved without an owner, as they are not identifiers of declarations.
+	name := g.makeUniqueIdentifier(g.composeIdentifier(names...))
+	g.usedQualifiedNames[name] = nil
+	g.instantiationNames[interfaceDeclaration.ReflectType] = name
+
+	return name
+}
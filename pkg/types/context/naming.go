@@ -0,0 +1,162 @@
+package context
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"unicode"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/naming_convention"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// NamingStrategy determines how types that share a name, e.g. `Config` types from different packages, are named.
+type NamingStrategy int
+
+const (
+	// NamingStrategyNumberSuffix names the first discovered type by its name, and distinguishes the others with number
+	// suffixes, e.g. "Config" and "Config2". Which type gets a suffix depends on the order of discovery.
+	NamingStrategyNumberSuffix NamingStrategy = iota
+	// NamingStrategyPackagePrefix prefixes the names of all types sharing a name with the names of their packages,
+	// e.g. "AuthConfig" and "DbConfig", regardless of the order of discovery. Types whose prefixed names are still
+	// shared are distinguished with number suffixes.
+	NamingStrategyPackagePrefix
+	// NamingStrategyError fails to add a type whose name is shared with another type.
+	NamingStrategyError
+)
+
+// TypeNamer is implemented by types that name their own declarations, overriding the names of the types. The method
+// is called on the zero value of the type. Interface types are named by their names regardless, as their zero value is
+// nil.
+type TypeNamer interface {
+	TypeName() string
+}
+
+var typeNamerType = reflect.TypeFor[TypeNamer]()
+
+// getTypeNamerName returns the name provided by the type's TypeName method, or an empty string if it has none.
+func getTypeNamerName(reflectType reflect.Type) string {
+	if reflectType.Kind() == reflect.Interface {
+		return ""
+	}
+
+	switch {
+	case reflectType.Implements(typeNamerType):
+		return reflect.Zero(reflectType).Interface().(TypeNamer).TypeName()
+	case reflect.PointerTo(reflectType).Implements(typeNamerType):
+		return reflect.New(reflectType).Interface().(TypeNamer).TypeName()
+	default:
+		return ""
+	}
+}
+
+// packagePrefix returns the name of the package with the provided path in the form of an identifier prefix, e.g.
+// "ApiTypes" for "github.com/acme/api-types".
+func packagePrefix(pkgPath string) string {
+	var stringBuilder strings.Builder
+	for _, word := range strings.FieldsFunc(path.Base(pkgPath), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		stringBuilder.WriteString(caser.String(word))
+	}
+	return stringBuilder.String()
+}
+
+// composeIdentifier joins the names into one identifier following the type naming convention of the context, e.g.
+// "auth_config" for "auth" and "Config" with snake case.
+func (g *Context) composeIdentifier(names ...string) string {
+	if g.TypeNameConvention == naming_convention.Preserve {
+		return strings.Join(names, "")
+	}
+
+	for i, name := range names {
+		names[i] = naming_convention.PascalCase.Apply(name)
+	}
+
+	return g.TypeNameConvention.Apply(strings.Join(names, ""))
+}
+
+func (g *Context) makeUniqueIdentifier(base string) string {
+	id := base
+	i := 2
+	for {
+		if _, exists := g.usedQualifiedNames[id]; !exists {
+			return id
+		}
+		id = fmt.Sprintf("%s%d", base, i)
+		i++
+	}
+}
+
+func (g *Context) makeUniqueAnonymousIdentifier() string {
+	g.anonymousCount++
+	return fmt.Sprintf("Anonymous%d", g.anonymousCount)
+}
+
+// rename changes the identifier of all declarations with the provided identifier, i.e. the declaration of a type or
+// all the instantiations of a generic type.
+func (g *Context) rename(identifier string, newIdentifier string) {
+	for _, typeDeclaration := range g.TypeDeclarations {
+		switch v := typeDeclaration.(type) {
+		case *type_declaration.InterfaceDeclaration:
+			if v.Identifier == identifier {
+				v.Identifier = newIdentifier
+			}
+		case *type_declaration.TypeAliasDeclaration:
+			if v.Identifier == identifier {
+				v.Identifier = newIdentifier
+			}
+		case *type_declaration.UnionDeclaration:
+			if v.Identifier == identifier {
+				v.Identifier = newIdentifier
+			}
+		}
+	}
+}
+
+// declareIdentifier returns and reserves a unique identifier for the declaration of the type, derived from its
+// TypeName method or its name according to the naming strategy of the context.
+func (g *Context) declareIdentifier(reflectType reflect.Type) (string, error) {
+	identifier := getTypeNamerName(reflectType)
+	if identifier == "" {
+		typeName, _ := motmedelReflect.GetTypeName(reflectType)
+		identifier = g.TypeNameConvention.Apply(caser.String(typeName))
+	}
+	if identifier == "" {
+		identifier = g.makeUniqueAnonymousIdentifier()
+	}
+
+	owner, taken := g.usedQualifiedNames[identifier]
+	if taken {
+		switch g.NamingStrategy {
+		case NamingStrategyNumberSuffix:
+			identifier = g.makeUniqueIdentifier(identifier)
+		case NamingStrategyPackagePrefix:
+			// The identifier remains reserved, without an owner, so that any other type with the name is prefixed too.
+			if owner != nil {
+				g.usedQualifiedNames[identifier] = nil
+				ownerIdentifier := g.makeUniqueIdentifier(g.composeIdentifier(packagePrefix(owner.PkgPath()), identifier))
+				g.usedQualifiedNames[ownerIdentifier] = owner
+				g.rename(identifier, ownerIdentifier)
+			}
+			identifier = g.makeUniqueIdentifier(g.composeIdentifier(packagePrefix(reflectType.PkgPath()), identifier))
+		case NamingStrategyError:
+			return "", motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %s", typeGenerationErrors.ErrNameCollision, identifier),
+				reflectType, owner,
+			)
+		default:
+			return "", motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: %d", typeGenerationErrors.ErrInvalidNamingStrategy, g.NamingStrategy),
+			)
+		}
+	}
+
+	g.usedQualifiedNames[identifier] = reflectType
+
+	return identifier, nil
+}
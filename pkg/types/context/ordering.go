@@ -0,0 +1,115 @@
+package context
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/vphpersson/type_generation/internal/declaration_position"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// Ordering determines the order in which producers render declarations.
+type Ordering int
+
+const (
+	// OrderingDependency orders declarations in the order in which they were discovered, which places each
+	// declaration after the declarations that it references, except within reference cycles. Output that must declare
+	// referenced types first, such as Postgres tables with foreign keys, requires this ordering.
+	OrderingDependency Ordering = iota
+	// OrderingAlphabetical orders declarations by their qualified names.
+	OrderingAlphabetical
+	// OrderingSourcePosition orders declarations by package path and then by the position of the declarations of
+	// their types in the source of their packages. Declarations of types without a source position, such as anonymous
+	// structs, come last, in the order in which they were discovered.
+	OrderingSourcePosition
+)
+
+func declarationReflectType(typeDeclaration type_declaration.TypeDeclaration) reflect.Type {
+	switch v := typeDeclaration.(type) {
+	case *type_declaration.InterfaceDeclaration:
+		return v.ReflectType
+	case *type_declaration.TypeAliasDeclaration:
+		return v.ReflectType
+	case *type_declaration.UnionDeclaration:
+		return v.ReflectType
+	default:
+		return nil
+	}
+}
+
+type sourcePosition struct {
+	pkgPath string
+	pos     token.Pos
+}
+
+func getSourcePosition(typeDeclaration type_declaration.TypeDeclaration) (*sourcePosition, error) {
+	reflectType := declarationReflectType(typeDeclaration)
+	if reflectType == nil || reflectType.PkgPath() == "" {
+		return nil, nil
+	}
+
+	typeName, _ := motmedelReflect.GetTypeName(reflectType)
+	if typeName == "" {
+		return nil, nil
+	}
+
+	pos, err := declaration_position.GetDeclarationPosition(reflectType.PkgPath(), typeName)
+	if err != nil {
+		return nil, motmedelErrors.New(fmt.Errorf("get declaration position: %w", err), reflectType)
+	}
+	if pos == token.NoPos {
+		return nil, nil
+	}
+
+	return &sourcePosition{pkgPath: reflectType.PkgPath(), pos: pos}, nil
+}
+
+// OrderedTypeDeclarations returns the declarations of the context in the order determined by its Ordering. Each
+// declaration occurs exactly once.
+func (g *Context) OrderedTypeDeclarations() ([]type_declaration.TypeDeclaration, error) {
+	typeDeclarations := slices.Clone(g.TypeDeclarationsInOrder)
+
+	switch g.Ordering {
+	case OrderingDependency:
+	case OrderingAlphabetical:
+		slices.SortStableFunc(typeDeclarations, func(a, b type_declaration.TypeDeclaration) int {
+			return strings.Compare(a.QualifiedName(), b.QualifiedName())
+		})
+	case OrderingSourcePosition:
+		sourcePositions := map[type_declaration.TypeDeclaration]*sourcePosition{}
+		for _, typeDeclaration := range typeDeclarations {
+			position, err := getSourcePosition(typeDeclaration)
+			if err != nil {
+				return nil, fmt.Errorf("get source position: %w", err)
+			}
+			sourcePositions[typeDeclaration] = position
+		}
+
+		slices.SortStableFunc(typeDeclarations, func(a, b type_declaration.TypeDeclaration) int {
+			aPosition, bPosition := sourcePositions[a], sourcePositions[b]
+			switch {
+			case aPosition == nil && bPosition == nil:
+				return 0
+			case aPosition == nil:
+				return 1
+			case bPosition == nil:
+				return -1
+			}
+			return cmp.Or(
+				strings.Compare(aPosition.pkgPath, bPosition.pkgPath),
+				cmp.Compare(aPosition.pos, bPosition.pos),
+			)
+		})
+	default:
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: %d", typeGenerationErrors.ErrInvalidOrdering, g.Ordering))
+	}
+
+	return typeDeclarations, nil
+}
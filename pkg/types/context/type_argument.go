@@ -0,0 +1,141 @@
+package context
+
+import (
+	"reflect"
+
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	"github.com/vphpersson/type_generation/internal/generic_type_info"
+	typeGenerationGenericTypeInfo "github.com/vphpersson/type_generation/pkg/types/generic_type_info"
+	"github.com/vphpersson/type_generation/pkg/types/shape"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// GetGenericTypeInfo returns the generic type info of an instantiation of a generic struct type, preferring that of
+// the type's declaration, or nil if the type is not generic or its info cannot be discovered.
+func (g *Context) GetGenericTypeInfo(structType reflect.Type) *typeGenerationGenericTypeInfo.GenericTypeInfo {
+	structType = motmedelReflect.RemoveIndirection(structType)
+
+	if interfaceDeclaration, ok := g.TypeDeclarations[structType].(*type_declaration.InterfaceDeclaration); ok {
+		return interfaceDeclaration.GenericTypeInfo
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+	if _, isGenericType := motmedelReflect.GetTypeName(structType); !isGenericType {
+		return nil
+	}
+
+	genericTypeInfo, err := generic_type_info.GetGenericTypeInfo(structType)
+	if err != nil {
+		return nil
+	}
+
+	return genericTypeInfo
+}
+
+// findTypeArgument returns the part of the type that corresponds to the type parameter in the type's shape.
+func (g *Context) findTypeArgument(
+	typeShape *shape.Shape,
+	reflectType reflect.Type,
+	typeParameterName string,
+) (reflect.Type, bool) {
+	if typeShape == nil || reflectType == nil {
+		return nil, false
+	}
+
+	find := func(shapes []*shape.Shape, reflectTypeAt func(int) reflect.Type) (reflect.Type, bool) {
+		for i, s := range shapes {
+			if argReflectType, ok := g.findTypeArgument(s, reflectTypeAt(i), typeParameterName); ok {
+				return argReflectType, true
+			}
+		}
+		return nil, false
+	}
+
+	kind := reflectType.Kind()
+
+	switch typeShape.Kind {
+	case shape.KindParam:
+		if typeShape.Param == typeParameterName {
+			return reflectType, true
+		}
+	case shape.KindPointer:
+		if kind == reflect.Pointer {
+			return g.findTypeArgument(typeShape.Elem, reflectType.Elem(), typeParameterName)
+		}
+	case shape.KindSlice, shape.KindArray:
+		if kind == reflect.Slice || kind == reflect.Array {
+			return g.findTypeArgument(typeShape.Elem, reflectType.Elem(), typeParameterName)
+		}
+	case shape.KindChan:
+		if kind == reflect.Chan {
+			return g.findTypeArgument(typeShape.Elem, reflectType.Elem(), typeParameterName)
+		}
+	case shape.KindMap:
+		if kind != reflect.Map {
+			break
+		}
+		if argReflectType, ok := g.findTypeArgument(typeShape.Key, reflectType.Key(), typeParameterName); ok {
+			return argReflectType, true
+		}
+		return g.findTypeArgument(typeShape.Elem, reflectType.Elem(), typeParameterName)
+	case shape.KindFunc:
+		if kind != reflect.Func || len(typeShape.Params) != reflectType.NumIn() ||
+			len(typeShape.Results) != reflectType.NumOut() {
+			break
+		}
+		if argReflectType, ok := find(typeShape.Params, reflectType.In); ok {
+			return argReflectType, true
+		}
+		return find(typeShape.Results, reflectType.Out)
+	case shape.KindGeneric:
+		// The type arguments of the nested instantiation are recovered from its own fields.
+		genericTypeInfo := g.GetGenericTypeInfo(reflectType)
+		if genericTypeInfo == nil || len(genericTypeInfo.TypeParameterNames) != len(typeShape.TypeArguments) {
+			break
+		}
+		return find(typeShape.TypeArguments, func(i int) reflect.Type {
+			nestedArgReflectType, _ := g.TypeArgument(reflectType, genericTypeInfo.TypeParameterNames[i])
+			return nestedArgReflectType
+		})
+	}
+
+	return nil, false
+}
+
+// TypeParameterNames returns the names of the type parameters of an instantiation of a generic struct type, or nil if
+// the type is not generic or its info cannot be discovered.
+func (g *Context) TypeParameterNames(structType reflect.Type) []string {
+	genericTypeInfo := g.GetGenericTypeInfo(structType)
+	if genericTypeInfo == nil {
+		return nil
+	}
+
+	return genericTypeInfo.TypeParameterNames
+}
+
+// TypeArgument returns the concrete type that the provided instantiation of a generic struct type uses for the type
+// parameter, derived from the first field whose type uses the parameter, possibly nested in other types.
+func (g *Context) TypeArgument(structType reflect.Type, typeParameterName string) (reflect.Type, bool) {
+	structType = motmedelReflect.RemoveIndirection(structType)
+
+	genericTypeInfo := g.GetGenericTypeInfo(structType)
+	if genericTypeInfo == nil {
+		return nil, false
+	}
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		fieldShape, ok := genericTypeInfo.FieldNameToShape[field.Name]
+		if !ok {
+			continue
+		}
+
+		if argReflectType, ok := g.findTypeArgument(fieldShape, field.Type, typeParameterName); ok {
+			return argReflectType, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+	"github.com/vphpersson/type_generation/pkg/types/type_mapping"
+)
+
+var (
+	typeMappingsMutex sync.RWMutex
+	typeMappings      = map[reflect.Type]*type_mapping.TypeMapping{}
+)
+
+// RegisterTypeMapping registers a mapping that overrides the representation of the type in all contexts, e.g. to
+// represent `uuid.UUID` as a string with the "uuid" format.
+func RegisterTypeMapping(reflectType reflect.Type, typeMapping *type_mapping.TypeMapping) {
+	typeMappingsMutex.Lock()
+	defer typeMappingsMutex.Unlock()
+
+	typeMappings[reflectType] = typeMapping
+}
+
+// GetTypeMapping returns the mapping of the type, preferring a mapping in the context's TypeMappings over one
+// registered with RegisterTypeMapping, or nil if the type has no mapping.
+func (g *Context) GetTypeMapping(reflectType reflect.Type) *type_mapping.TypeMapping {
+	if typeMapping, ok := g.TypeMappings[reflectType]; ok {
+		return typeMapping
+	}
+
+	typeMappingsMutex.RLock()
+	defer typeMappingsMutex.RUnlock()
+
+	return typeMappings[reflectType]
+}
+
+type MarshalerKind int
+
+const (
+	MarshalerKindNone MarshalerKind = iota
+	// MarshalerKindText is a type implementing encoding.TextMarshaler, which json.Marshal() encodes as a string.
+	MarshalerKindText
+	// MarshalerKindJSON is a type implementing only json.Marshaler, which may encode as any JSON value.
+	MarshalerKindJSON
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func implements(reflectType reflect.Type, interfaceType reflect.Type) bool {
+	return reflectType.Implements(interfaceType) || reflect.PointerTo(reflectType).Implements(interfaceType)
+}
+
+// GetMarshalerKind reports whether the type customizes its JSON encoding. A type implementing both
+// encoding.TextMarshaler and json.Marshaler is assumed to encode as a string, which is conventional (e.g. decimal
+// types).
+func GetMarshalerKind(reflectType reflect.Type) MarshalerKind {
+	// Interface types trivially implement the interfaces they embed, but are encoded by their dynamic value.
+	if reflectType.Kind() == reflect.Interface {
+		return MarshalerKindNone
+	}
+
+	switch {
+	case implements(reflectType, textMarshalerType):
+		return MarshalerKindText
+	case implements(reflectType, jsonMarshalerType):
+		return MarshalerKindJSON
+	default:
+		return MarshalerKindNone
+	}
+}
+
+// isOpaque reports whether the type is represented without regard to its structure by all producers, which is the
+// case for types that customize their JSON encoding. Types with a mapping are still declared, as producers for which
+// the mapping has no representation fall back to the declarations.
+func (g *Context) isOpaque(reflectType reflect.Type) bool {
+	return GetMarshalerKind(reflectType) != MarshalerKindNone
+}
+
+// RemoveMappedTypeDeclarations returns the declarations except those of types whose mappings the provided function
+// reports to have a representation for a producer, which represents the types with the mappings rather than by
+// their declarations.
+func (g *Context) RemoveMappedTypeDeclarations(
+	typeDeclarations []type_declaration.TypeDeclaration,
+	hasRepresentation func(typeMapping *type_mapping.TypeMapping) bool,
+) []type_declaration.TypeDeclaration {
+	return slices.DeleteFunc(typeDeclarations, func(typeDeclaration type_declaration.TypeDeclaration) bool {
+		reflectType := declarationReflectType(typeDeclaration)
+		if reflectType == nil {
+			return false
+		}
+
+		typeMapping := g.GetTypeMapping(reflectType)
+		return typeMapping != nil && hasRepresentation(typeMapping)
+	})
+}
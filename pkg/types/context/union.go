@@ -0,0 +1,162 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+	"github.com/vphpersson/type_generation/pkg/types/type_declaration"
+)
+
+// jsonPropertyName returns the name with which json.Marshal() encodes the field, or an empty string if it is skipped.
+func jsonPropertyName(field *reflect.StructField) string {
+	jsonTag := motmedelJsonTag.New(field.Tag.Get("json"))
+	if jsonTag == nil {
+		return field.Name
+	}
+	if jsonTag.Skip {
+		return ""
+	}
+	if jsonTag.Name != "" {
+		return jsonTag.Name
+	}
+	return field.Name
+}
+
+// hasDiscriminator reports whether the interface declaration has a string property encoded with the discriminator
+// name.
+func hasDiscriminator(interfaceDeclaration *type_declaration.InterfaceDeclaration, discriminator string) bool {
+	return slices.ContainsFunc(interfaceDeclaration.Properties, func(property *type_declaration.PropertySignature) bool {
+		if property == nil || property.Field == nil || jsonPropertyName(property.Field) != discriminator {
+			return false
+		}
+		return motmedelReflect.RemoveIndirection(property.Field.Type).Kind() == reflect.String
+	})
+}
+
+// RegisterUnion declares the structs implementing an interface type as the variants of a tagged union, mapping the
+// values of the discriminator property to the variant types. Fields of the interface type are then represented as
+// the union of the variants rather than as any value. Each variant must have a string field encoded with the
+// discriminator name.
+func (g *Context) RegisterUnion(
+	interfaceType reflect.Type,
+	discriminator string,
+	variants map[string]reflect.Type,
+) (*type_declaration.UnionDeclaration, error) {
+	if interfaceType == nil {
+		return nil, motmedelErrors.NewWithTrace(fmt.Errorf("%w: nil interface type", typeGenerationErrors.ErrInvalidUnion))
+	}
+
+	if kind := interfaceType.Kind(); kind != reflect.Interface {
+		return nil, motmedelErrors.NewWithTrace(typeGenerationErrors.ErrUnsupportedKind, kind)
+	}
+
+	if interfaceType.Name() == "" {
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: unnamed interface type", typeGenerationErrors.ErrInvalidUnion),
+			interfaceType,
+		)
+	}
+
+	if discriminator == "" {
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: empty discriminator", typeGenerationErrors.ErrInvalidUnion),
+			interfaceType,
+		)
+	}
+
+	if len(variants) == 0 {
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: no variants", typeGenerationErrors.ErrInvalidUnion),
+			interfaceType,
+		)
+	}
+
+	var identifier string
+	switch existingTypeDeclaration := g.TypeDeclarations[interfaceType].(type) {
+	case nil:
+		var err error
+		identifier, err = g.declareIdentifier(interfaceType)
+		if err != nil {
+			return nil, fmt.Errorf("declare identifier: %w", err)
+		}
+	case *type_declaration.TypeAliasDeclaration:
+		// A field of the interface type was added before the registration; take over its declaration.
+		identifier = existingTypeDeclaration.Identifier
+		g.TypeDeclarationsInOrder = slices.DeleteFunc(
+			g.TypeDeclarationsInOrder,
+			func(typeDeclaration type_declaration.TypeDeclaration) bool {
+				return typeDeclaration == existingTypeDeclaration
+			},
+		)
+	default:
+		return nil, motmedelErrors.NewWithTrace(
+			fmt.Errorf("%w: already declared", typeGenerationErrors.ErrInvalidUnion),
+			interfaceType,
+		)
+	}
+
+	unionDeclaration := &type_declaration.UnionDeclaration{
+		Identifier:    identifier,
+		Discriminator: discriminator,
+		ReflectType:   interfaceType,
+	}
+
+	// Sort the variants by tag for a deterministic output.
+	for _, tag := range slices.Sorted(maps.Keys(variants)) {
+		variantType := variants[tag]
+		if tag == "" || variantType == nil {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: empty variant", typeGenerationErrors.ErrInvalidUnion),
+				interfaceType, tag,
+			)
+		}
+
+		if !implements(variantType, interfaceType) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: variant does not implement the interface", typeGenerationErrors.ErrInvalidUnion),
+				interfaceType, variantType,
+			)
+		}
+
+		interfaceDeclaration, err := g.GetOrCreateInterfaceDeclaration(variantType)
+		if err != nil {
+			return nil, motmedelErrors.New(fmt.Errorf("get or create interface declaration: %w", err), variantType)
+		}
+		if interfaceDeclaration == nil || interfaceDeclaration.GenericTypeInfo != nil {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: variant is not a plain struct", typeGenerationErrors.ErrInvalidUnion),
+				interfaceType, variantType,
+			)
+		}
+
+		if !hasDiscriminator(interfaceDeclaration, discriminator) {
+			return nil, motmedelErrors.NewWithTrace(
+				fmt.Errorf("%w: variant has no string discriminator property", typeGenerationErrors.ErrInvalidUnion),
+				interfaceType, variantType, discriminator,
+			)
+		}
+
+		unionDeclaration.Variants = append(
+			unionDeclaration.Variants,
+			&type_declaration.UnionVariant{Tag: tag, InterfaceDeclaration: interfaceDeclaration},
+		)
+	}
+
+	g.TypeDeclarations[interfaceType] = unionDeclaration
+	g.TypeDeclarationsInOrder = append(g.TypeDeclarationsInOrder, unionDeclaration)
+
+	return unionDeclaration, nil
+}
+
+// GetUnionDeclaration returns the union declaration registered for the interface type, or nil if there is none.
+func (g *Context) GetUnionDeclaration(reflectType reflect.Type) *type_declaration.UnionDeclaration {
+	reflectType = motmedelReflect.RemoveIndirection(reflectType)
+	unionDeclaration, _ := g.TypeDeclarations[reflectType].(*type_declaration.UnionDeclaration)
+	return unionDeclaration
+}
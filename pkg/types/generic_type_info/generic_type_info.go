@@ -1,9 +1,11 @@
 package generic_type_info
 
-import "github.com/vphpersson/type_generation/pkg/types/shape"
+import (
+	"github.com/vphpersson/type_generation/pkg/types/shape"
+)
 
 type GenericTypeInfo struct {
-	TypeParameterNames           []string
-	FieldNameToShape             map[string]shape.Shape
-	TypeParameterNameToFieldName map[string]string
+	TypeParameterNames []string
+	// FieldNameToShape maps the names of the fields whose types use type parameters to the shapes of their types.
+	FieldNameToShape map[string]*shape.Shape
 }
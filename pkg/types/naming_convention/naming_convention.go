@@ -0,0 +1,98 @@
+package naming_convention
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingConvention transforms names, such as the names of Go types and fields, into names of another casing.
+type NamingConvention int
+
+const (
+	// Preserve leaves names unchanged.
+	Preserve NamingConvention = iota
+	// CamelCase transforms names into camel case, e.g. "HTTPServerID" -> "httpServerId".
+	CamelCase
+	// PascalCase transforms names into Pascal case, e.g. "HTTPServerID" -> "HttpServerId".
+	PascalCase
+	// SnakeCase transforms names into snake case, e.g. "HTTPServerID" -> "http_server_id".
+	SnakeCase
+	// KebabCase transforms names into kebab case, e.g. "HTTPServerID" -> "http-server-id".
+	KebabCase
+)
+
+// isPluralSuffix reports whether the rune at the index is a lowercase "s" ending an acronym, e.g. in "IDs".
+func isPluralSuffix(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// Words splits a name into its words, at separators and case changes. Acronyms form words of their own, e.g.
+// "HTTPServerID" -> ["HTTP", "Server", "ID"], and digits belong to the preceding word, e.g. "HTTP2Server" ->
+// ["HTTP2", "Server"].
+func Words(name string) []string {
+	var words []string
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if !unicode.IsUpper(runes[i]) {
+				continue
+			}
+
+			previous := runes[i-1]
+			endsAcronym := unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+				!isPluralSuffix(runes, i+1)
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || endsAcronym {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Apply transforms the name according to the naming convention.
+func (n NamingConvention) Apply(name string) string {
+	if n == Preserve {
+		return name
+	}
+
+	words := Words(name)
+	if len(words) == 0 {
+		return name
+	}
+
+	switch n {
+	case CamelCase:
+		for i, word := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(word)
+			} else {
+				words[i] = capitalize(word)
+			}
+		}
+		return strings.Join(words, "")
+	case PascalCase:
+		for i, word := range words {
+			words[i] = capitalize(word)
+		}
+		return strings.Join(words, "")
+	case SnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	default:
+		return name
+	}
+}
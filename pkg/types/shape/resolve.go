@@ -0,0 +1,115 @@
+package shape
+
+import (
+	"reflect"
+
+	motmedelErrors "github.com/Motmedel/utils_go/pkg/errors"
+	typeGenerationErrors "github.com/vphpersson/type_generation/pkg/errors"
+)
+
+// Producer produces the types of a producer for the parts of a shape.
+type Producer[T any] interface {
+	// Type returns the type of a type whose expression does not use type parameters.
+	Type(reflectType reflect.Type) (T, error)
+	// KeyType returns the type of a map key type whose expression does not use type parameters.
+	KeyType(reflectType reflect.Type) (T, error)
+	// TypeParameter returns a reference to a type parameter.
+	TypeParameter(name string) T
+	ArrayType(itemsType T) T
+	MapType(keyType T, valueType T) T
+	// TypeArguments returns the type arguments of a type returned by Type that refers to a generic type, which are
+	// replaced in place, or nil for other types.
+	TypeArguments(t T) []T
+	// TypeParameterNames returns the names of the type parameters of an instantiation of a generic struct type.
+	TypeParameterNames(structType reflect.Type) []string
+	// TypeArgument returns the concrete type that an instantiation of a generic struct type uses for a type
+	// parameter.
+	TypeArgument(structType reflect.Type, typeParameterName string) (reflect.Type, bool)
+}
+
+// Resolve returns the type of a generic struct's field type, in which the parts using type parameters are replaced by
+// the parameters, e.g. `T[][]` for a `[][]T` field and `Wrapper<T>` for a `Wrapper[T]` field.
+func Resolve[T any](producer Producer[T], typeShape *Shape, reflectType reflect.Type) (T, error) {
+	var zero T
+
+	if typeShape == nil {
+		return producer.Type(reflectType)
+	}
+
+	kind := reflectType.Kind()
+
+	switch typeShape.Kind {
+	case KindParam:
+		return producer.TypeParameter(typeShape.Param), nil
+	case KindPointer:
+		if kind == reflect.Pointer {
+			return Resolve(producer, typeShape.Elem, reflectType.Elem())
+		}
+	case KindSlice, KindArray:
+		if kind == reflect.Slice || kind == reflect.Array {
+			itemsType, err := Resolve(producer, typeShape.Elem, reflectType.Elem())
+			if err != nil {
+				return zero, err
+			}
+			return producer.ArrayType(itemsType), nil
+		}
+	case KindMap:
+		if kind != reflect.Map {
+			break
+		}
+
+		var keyType T
+		var err error
+		if typeShape.Key != nil {
+			keyType, err = Resolve(producer, typeShape.Key, reflectType.Key())
+		} else {
+			keyType, err = producer.KeyType(reflectType.Key())
+		}
+		if err != nil {
+			return zero, err
+		}
+
+		valueType, err := Resolve(producer, typeShape.Elem, reflectType.Elem())
+		if err != nil {
+			return zero, err
+		}
+
+		return producer.MapType(keyType, valueType), nil
+	case KindGeneric:
+		genericType, err := producer.Type(reflectType)
+		if err != nil {
+			return zero, err
+		}
+
+		typeArguments := producer.TypeArguments(genericType)
+		typeParameterNames := producer.TypeParameterNames(reflectType)
+		if typeArguments == nil || typeParameterNames == nil || len(typeArguments) != len(typeShape.TypeArguments) {
+			return genericType, nil
+		}
+
+		for i, typeArgumentShape := range typeShape.TypeArguments {
+			if typeArgumentShape == nil {
+				continue
+			}
+
+			typeParameterName := typeParameterNames[i]
+			argReflectType, ok := producer.TypeArgument(reflectType, typeParameterName)
+			if !ok {
+				return zero, motmedelErrors.NewWithTrace(
+					typeGenerationErrors.ErrNoStructField,
+					reflectType, typeParameterName,
+				)
+			}
+
+			typeArguments[i], err = Resolve(producer, typeArgumentShape, argReflectType)
+			if err != nil {
+				return zero, err
+			}
+		}
+
+		return genericType, nil
+	default:
+	}
+
+	return producer.Type(reflectType)
+}
@@ -3,15 +3,32 @@ package shape
 type Kind int
 
 const (
-	KindDirect Kind = iota
+	// KindParam is a type parameter.
+	KindParam Kind = iota
 	KindPointer
 	KindSlice
 	KindArray
-	KindMapValue
-	KindMapKey
+	KindMap
+	KindChan
+	KindFunc
+	// KindGeneric is an instantiation of a generic type, e.g. `Wrapper[T]`.
+	KindGeneric
 )
 
+// Shape is the expression of a type that uses type parameters, e.g. `map[string][]T` or `Wrapper[T]`. Parts of the
+// expression that do not use any type parameter are nil.
 type Shape struct {
+	Kind Kind
+	// Param is the name of the type parameter, for KindParam.
 	Param string
-	Kind  Kind
+	// Elem is the element type, for KindPointer, KindSlice, KindArray, KindMap and KindChan.
+	Elem *Shape
+	// Key is the key type, for KindMap.
+	Key *Shape
+	// TypeArguments are the type arguments, for KindGeneric.
+	TypeArguments []*Shape
+	// Params are the parameter types, for KindFunc.
+	Params []*Shape
+	// Results are the result types, for KindFunc.
+	Results []*Shape
 }
@@ -10,12 +10,19 @@ type PropertySignature struct {
 	Identifier string
 	Field *reflect.StructField
 	Optional   bool
+	// Nullable is true if json.Marshal() encodes the zero value of the field as `null`, i.e. for pointer, slice, map
+	// and interface fields that are not omitted when empty.
+	Nullable bool
+	// Quoted is true if the field has the `string` option in its `json` tag and is of a type to which the option
+	// applies, in which case json.Marshal() encodes the field's value inside a JSON string.
+	Quoted bool
 }
 
 type InterfaceDeclaration struct {
 	Identifier      string
 	Properties      []*PropertySignature
 	GenericTypeInfo *generic_type_info.GenericTypeInfo
+	ReflectType     reflect.Type
 }
 
 func (i *InterfaceDeclaration) QualifiedName() string {
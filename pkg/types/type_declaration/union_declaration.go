@@ -0,0 +1,22 @@
+package type_declaration
+
+import "reflect"
+
+// UnionVariant is a member of a tagged union, identified by the value of the union's discriminator property.
+type UnionVariant struct {
+	Tag                  string
+	InterfaceDeclaration *InterfaceDeclaration
+}
+
+// UnionDeclaration is a tagged union of the structs implementing a Go interface, which all have a string
+// discriminator property whose value identifies the variant.
+type UnionDeclaration struct {
+	Identifier    string
+	Discriminator string
+	Variants      []*UnionVariant
+	ReflectType   reflect.Type
+}
+
+func (u *UnionDeclaration) QualifiedName() string {
+	return u.Identifier
+}
@@ -0,0 +1,18 @@
+package type_mapping
+
+// TypeMapping overrides the representation of a type in the producers. A producer whose field is empty falls back to
+// how the type is marshalled (see context.GetMarshalerKind), or to the declaration of the type.
+type TypeMapping struct {
+	// TypeScript is a TypeScript type expression, e.g. "string".
+	TypeScript string
+	// JSONSchema is a JSON Schema fragment, e.g. {"type": "string", "format": "uuid"}.
+	JSONSchema map[string]any
+	// Postgres is a Postgres column type, e.g. "uuid".
+	Postgres string
+	// CSharp is a C# type, e.g. "Guid".
+	CSharp string
+	// Java is a Java reference type, e.g. "UUID".
+	Java string
+	// Avro is an Avro schema, e.g. map[string]any{"type": "string", "logicalType": "uuid"}.
+	Avro any
+}
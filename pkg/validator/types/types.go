@@ -0,0 +1,17 @@
+package types
+
+import "fmt"
+
+// FieldError describes a value that violates a constraint of the `jsonschema` tag of its field.
+type FieldError struct {
+	// Path is the JSON Pointer of the value in the JSON encoding of the validated value, e.g. "/items/0/name".
+	Path string
+	// Keyword is the JSON Schema keyword of the violated constraint, e.g. "minLength".
+	Keyword string
+	// Message describes the violation.
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
@@ -0,0 +1,605 @@
+package validator
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	motmedelJsonTag "github.com/Motmedel/utils_go/pkg/json/types/tag"
+	motmedelReflect "github.com/Motmedel/utils_go/pkg/reflect"
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	"github.com/vphpersson/type_generation/pkg/producers/jsonschema/types/tag"
+	typeGenerationContext "github.com/vphpersson/type_generation/pkg/types/context"
+	"github.com/vphpersson/type_generation/pkg/validator/types"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+var hostnamePattern = regexp.MustCompile(
+	`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`,
+)
+
+// formatValidators validate the formats with which values are checked. As in JSON Schema, other formats are ignored.
+var formatValidators = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05.999999999Z07:00", s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	},
+	"uuid": uuidPattern.MatchString,
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"ipv4": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is4()
+	},
+	"ipv6": func(s string) bool {
+		addr, err := netip.ParseAddr(s)
+		return err == nil && addr.Is6()
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	},
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// patterns caches the compiled patterns of tags.
+var patterns sync.Map
+
+// compilePattern compiles the pattern of a tag. Go regular expressions are used in place of the ECMA-262 regular
+// expressions of JSON Schema, which they match for the common syntax.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if patternRegexp, ok := patterns.Load(pattern); ok {
+		return patternRegexp.(*regexp.Regexp), nil
+	}
+
+	patternRegexp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("regexp compile: %w", err)
+	}
+	patterns.Store(pattern, patternRegexp)
+
+	return patternRegexp, nil
+}
+
+func isTime(t reflect.Type) bool {
+	return t.Name() == "Time" && t.PkgPath() == "time"
+}
+
+// isEmptyValue reports whether json.Marshal() omits the value of a field with the `omitempty` option.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return value.IsZero()
+	default:
+		return false
+	}
+}
+
+// isZeroValue reports whether json.Marshal() omits the value of a field with the `omitzero` option.
+func isZeroValue(value reflect.Value) bool {
+	if isZeroer, ok := value.Interface().(interface{ IsZero() bool }); ok {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return true
+		}
+		return isZeroer.IsZero()
+	}
+	return value.IsZero()
+}
+
+// marshalText returns the text encoding of a value whose type implements encoding.TextMarshaler, possibly with a
+// pointer receiver.
+func marshalText(value reflect.Value) (string, error) {
+	if !value.Type().Implements(textMarshalerType) {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer
+	}
+
+	text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", fmt.Errorf("marshal text: %w", err)
+	}
+
+	return string(text), nil
+}
+
+func escapePathSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
+
+type validator struct {
+	options     jsonschemaTypes.Options
+	fieldErrors []types.FieldError
+}
+
+func (v *validator) addError(path string, keyword string, format string, args ...any) {
+	v.fieldErrors = append(
+		v.fieldErrors,
+		types.FieldError{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)},
+	)
+}
+
+// validateLength checks the length constraints of a property described as a string. As in the schema, non-empty
+// strings are required with the NonEmptyStrings option, unless the tag specifies another minimum length.
+func (v *validator) validateLength(length int, jsonschemaTag *tag.Tag, path string) {
+	minLength := 0
+	if v.options.NonEmptyStrings {
+		minLength = 1
+	}
+	var maxLength *int
+	if jsonschemaTag != nil {
+		if jsonschemaTag.MinLength != nil {
+			minLength = *jsonschemaTag.MinLength
+		}
+		maxLength = jsonschemaTag.MaxLength
+	}
+
+	if length < minLength {
+		v.addError(path, "minLength", "length %d is less than the minimum length %d", length, minLength)
+	}
+	if maxLength != nil && length > *maxLength {
+		v.addError(path, "maxLength", "length %d is greater than the maximum length %d", length, *maxLength)
+	}
+}
+
+// validateValues checks the enum and const constraints of the tag, whose values are parsed as values of the schema
+// type. Numbers are compared by their mathematical values.
+func (v *validator) validateValues(value any, schemaType string, jsonschemaTag *tag.Tag, path string) {
+	if jsonschemaTag == nil {
+		return
+	}
+
+	equals := func(rawValue string) bool {
+		parsedValue, err := tag.ParseValue(rawValue, schemaType)
+		if err != nil {
+			v.addError(path, "", "%v", err)
+			return false
+		}
+		if integer, ok := parsedValue.(int64); ok {
+			parsedValue = float64(integer)
+		}
+		return parsedValue == value
+	}
+
+	if enum := jsonschemaTag.Enum; enum != nil && !slices.ContainsFunc(enum, equals) {
+		v.addError(path, "enum", "%v is not one of %s", value, strings.Join(enum, ", "))
+	}
+	if constValue := jsonschemaTag.Const; constValue != nil && !equals(*constValue) {
+		v.addError(path, "const", "%v is not %s", value, *constValue)
+	}
+}
+
+// validateString checks the constraints of a property described as a string, whose length JSON Schema measures in
+// code points.
+func (v *validator) validateString(s string, jsonschemaTag *tag.Tag, path string) {
+	v.validateLength(utf8.RuneCountInString(s), jsonschemaTag, path)
+
+	if jsonschemaTag == nil {
+		return
+	}
+
+	format := strings.TrimSpace(jsonschemaTag.Format)
+	if validateFormat, ok := formatValidators[format]; ok && !validateFormat(s) {
+		v.addError(path, "format", "%s is not a valid %s", strconv.Quote(s), format)
+	}
+
+	if pattern := jsonschemaTag.Pattern; pattern != "" {
+		patternRegexp, err := compilePattern(pattern)
+		if err != nil {
+			v.addError(path, "", "%v", err)
+		} else if !patternRegexp.MatchString(s) {
+			v.addError(path, "pattern", "%s does not match the pattern %s", strconv.Quote(s), pattern)
+		}
+	}
+
+	v.validateValues(s, "string", jsonschemaTag, path)
+}
+
+func (v *validator) validateNumber(number float64, schemaType string, jsonschemaTag *tag.Tag, path string) {
+	if jsonschemaTag == nil {
+		return
+	}
+
+	if minimum := jsonschemaTag.Minimum; minimum != nil && number < *minimum {
+		v.addError(path, "minimum", "%v is less than the minimum %v", number, *minimum)
+	}
+	if maximum := jsonschemaTag.Maximum; maximum != nil && number > *maximum {
+		v.addError(path, "maximum", "%v is greater than the maximum %v", number, *maximum)
+	}
+	if exclusiveMinimum := jsonschemaTag.ExclusiveMinimum; exclusiveMinimum != nil && number <= *exclusiveMinimum {
+		v.addError(path, "exclusiveMinimum", "%v is not greater than %v", number, *exclusiveMinimum)
+	}
+	if exclusiveMaximum := jsonschemaTag.ExclusiveMaximum; exclusiveMaximum != nil && number >= *exclusiveMaximum {
+		v.addError(path, "exclusiveMaximum", "%v is not less than %v", number, *exclusiveMaximum)
+	}
+	if multipleOf := jsonschemaTag.MultipleOf; multipleOf != nil && *multipleOf > 0 {
+		// The quotient is compared with a tolerance, as binary floating-point numbers cannot represent most decimal
+		// fractions, e.g. 0.3 / 0.1.
+		quotient := number / *multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.addError(path, "multipleOf", "%v is not a multiple of %v", number, *multipleOf)
+		}
+	}
+
+	v.validateValues(number, schemaType, jsonschemaTag, path)
+}
+
+// validateItems checks the constraints of a property described as an array. As in the schema, non-empty arrays are
+// required with the NonEmptyArrays option, unless the tag specifies another minimum number of items.
+func (v *validator) validateItems(value reflect.Value, jsonschemaTag *tag.Tag, path string) {
+	length := value.Len()
+
+	minItems := 0
+	if v.options.NonEmptyArrays {
+		minItems = 1
+	}
+	var maxItems *int
+	if jsonschemaTag != nil {
+		if jsonschemaTag.MinItems != nil {
+			minItems = *jsonschemaTag.MinItems
+		}
+		maxItems = jsonschemaTag.MaxItems
+	}
+
+	if length < minItems {
+		v.addError(path, "minItems", "%d items are fewer than the minimum %d", length, minItems)
+	}
+	if maxItems != nil && length > *maxItems {
+		v.addError(path, "maxItems", "%d items are more than the maximum %d", length, *maxItems)
+	}
+
+	if jsonschemaTag != nil && jsonschemaTag.UniqueItems {
+		// Items are compared by their JSON encodings.
+		encodedItems := map[string]struct{}{}
+		for i := range length {
+			data, err := json.Marshal(value.Index(i).Interface())
+			if err != nil {
+				v.addError(path, "", "json marshal: %v", err)
+				return
+			}
+			if _, ok := encodedItems[string(data)]; ok {
+				v.addError(path, "uniqueItems", "item %d is a duplicate", i)
+				return
+			}
+			encodedItems[string(data)] = struct{}{}
+		}
+	}
+}
+
+func (v *validator) validateProperties(length int, jsonschemaTag *tag.Tag, path string) {
+	if jsonschemaTag == nil {
+		return
+	}
+
+	if minProperties := jsonschemaTag.MinProperties; minProperties != nil && length < *minProperties {
+		v.addError(path, "minProperties", "%d properties are fewer than the minimum %d", length, *minProperties)
+	}
+	if maxProperties := jsonschemaTag.MaxProperties; maxProperties != nil && length > *maxProperties {
+		v.addError(path, "maxProperties", "%d properties are more than the maximum %d", length, *maxProperties)
+	}
+}
+
+// validateProperty checks the value of a struct field against the constraints that the JSON Schema producer
+// describes for it, and validates the values that it contains.
+func (v *validator) validateProperty(value reflect.Value, jsonschemaTag *tag.Tag, path string) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	valueType := value.Type()
+	if !isTime(valueType) {
+		switch typeGenerationContext.GetMarshalerKind(valueType) {
+		case typeGenerationContext.MarshalerKindText:
+			text, err := marshalText(value)
+			if err != nil {
+				v.addError(path, "", "%v", err)
+				return
+			}
+			v.validateString(text, jsonschemaTag, path)
+			return
+		case typeGenerationContext.MarshalerKindJSON:
+			// The encoding is unknown, and the schema accepts any value.
+			return
+		default:
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		v.validateString(value.String(), jsonschemaTag, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.validateNumber(float64(value.Int()), "integer", jsonschemaTag, path)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.validateNumber(float64(value.Uint()), "integer", jsonschemaTag, path)
+	case reflect.Float32, reflect.Float64:
+		v.validateNumber(value.Float(), "number", jsonschemaTag, path)
+	case reflect.Bool:
+		v.validateValues(value.Bool(), "boolean", jsonschemaTag, path)
+	case reflect.Struct:
+		if isTime(valueType) {
+			text, _ := marshalText(value)
+			v.validateString(text, jsonschemaTag, path)
+			return
+		}
+		v.validateValue(value, path)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return
+		}
+		if value.Kind() == reflect.Slice && valueType.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings, unlike byte arrays.
+			v.validateString(base64.StdEncoding.EncodeToString(value.Bytes()), jsonschemaTag, path)
+			return
+		}
+		v.validateItems(value, jsonschemaTag, path)
+		v.validateValue(value, path)
+	case reflect.Map:
+		if value.IsNil() {
+			return
+		}
+		v.validateProperties(value.Len(), jsonschemaTag, path)
+		v.validateValue(value, path)
+	default:
+		v.validateValue(value, path)
+	}
+}
+
+// validateValue validates the values that the value contains, i.e. the fields of structs and the elements of
+// slices, arrays and maps.
+func (v *validator) validateValue(value reflect.Value, path string) {
+	if !value.IsValid() {
+		return
+	}
+
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	if valueType := value.Type(); isTime(valueType) ||
+		typeGenerationContext.GetMarshalerKind(valueType) != typeGenerationContext.MarshalerKindNone {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		v.validateStruct(value, path)
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			v.validateValue(value.Index(i), path+"/"+strconv.Itoa(i))
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			v.validateValue(value.MapIndex(key), path+"/"+escapePathSegment(fmt.Sprint(key.Interface())))
+		}
+	default:
+	}
+}
+
+// structField is a field of a struct, or of a struct embedded in it, that json.Marshal() encodes.
+type structField struct {
+	field         reflect.StructField
+	value         reflect.Value
+	jsonTag       *motmedelJsonTag.Tag
+	jsonschemaTag *tag.Tag
+	name          string
+	// index is the sequence of field indices that reaches the field from the outer struct.
+	index  []int
+	tagged bool
+}
+
+// structFields returns the fields of the struct that json.Marshal() encodes, in the order in which it encodes them.
+// As with encoding/json, the fields of embedded structs are promoted, and of fields with the same name, the shallowest
+// one is chosen, and of those, the tagged one. If no single field is chosen, the conflicting fields are dropped.
+func (v *validator) structFields(value reflect.Value, path string) []structField {
+	// The value of an embedded struct is invalid if it is reached through a nil pointer. Its fields still conflict
+	// with others, as the names of the encoded fields are determined by the types.
+	type embeddedStruct struct {
+		structType reflect.Type
+		value      reflect.Value
+		index      []int
+	}
+
+	var fields []structField
+	current := []embeddedStruct{{structType: value.Type(), value: value}}
+	visited := map[reflect.Type]struct{}{}
+	for len(current) > 0 {
+		var next []embeddedStruct
+		for _, embedded := range current {
+			structType := embedded.structType
+			// A struct embedded at a shallower depth takes precedence over its embeddings at this depth.
+			if _, ok := visited[structType]; ok {
+				continue
+			}
+			visited[structType] = struct{}{}
+
+			for i := range structType.NumField() {
+				field := structType.Field(i)
+				fieldType := motmedelReflect.RemoveIndirection(field.Type)
+
+				var fieldValue reflect.Value
+				if embedded.value.IsValid() {
+					fieldValue = embedded.value.Field(i)
+				}
+				index := append(slices.Clone(embedded.index), i)
+
+				jsonTag := motmedelJsonTag.New(field.Tag.Get("json"))
+				jsonName := ""
+				if jsonTag != nil {
+					if jsonTag.Skip {
+						continue
+					}
+					jsonName = jsonTag.Name
+				}
+
+				if field.Anonymous && jsonName == "" && fieldType.Kind() == reflect.Struct {
+					if fieldValue.Kind() == reflect.Pointer {
+						if fieldValue.IsNil() {
+							fieldValue = reflect.Value{}
+						} else {
+							fieldValue = fieldValue.Elem()
+						}
+					}
+					next = append(next, embeddedStruct{structType: fieldType, value: fieldValue, index: index})
+					continue
+				}
+				// The exported fields of embedded structs are promoted even if the struct types are unexported.
+				if !field.IsExported() {
+					continue
+				}
+
+				rawJsonschemaTag := field.Tag.Get("jsonschema")
+				jsonschemaTag, err := tag.New(rawJsonschemaTag)
+				if err != nil {
+					v.addError(path, "", "invalid jsonschema tag of field %s: %v", field.Name, err)
+					continue
+				}
+				if jsonschemaTag != nil && jsonschemaTag.Skip {
+					continue
+				}
+
+				name := field.Name
+				if jsonName != "" {
+					name = jsonName
+				}
+				if jsonschemaTag != nil && jsonschemaTag.Name != "" {
+					name = jsonschemaTag.Name
+				}
+
+				fields = append(
+					fields,
+					structField{
+						field:         field,
+						value:         fieldValue,
+						jsonTag:       jsonTag,
+						jsonschemaTag: jsonschemaTag,
+						name:          name,
+						index:         index,
+						tagged:        jsonName != "" || (jsonschemaTag != nil && jsonschemaTag.Name != ""),
+					},
+				)
+			}
+		}
+		current = next
+	}
+
+	slices.SortStableFunc(fields, func(a, b structField) int {
+		if order := cmp.Or(strings.Compare(a.name, b.name), cmp.Compare(len(a.index), len(b.index))); order != 0 {
+			return order
+		}
+		switch {
+		case a.tagged && !b.tagged:
+			return -1
+		case !a.tagged && b.tagged:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var dominantFields []structField
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+
+		candidates := fields[i:j]
+		if len(candidates) == 1 || len(candidates[1].index) > len(candidates[0].index) ||
+			(candidates[0].tagged && !candidates[1].tagged) {
+			dominantFields = append(dominantFields, candidates[0])
+		}
+		i = j
+	}
+
+	slices.SortFunc(dominantFields, func(a, b structField) int { return slices.Compare(a.index, b.index) })
+
+	return dominantFields
+}
+
+// validateStruct validates the fields of the struct, including the promoted fields of embedded structs.
+func (v *validator) validateStruct(value reflect.Value, path string) {
+	for _, structField := range v.structFields(value, path) {
+		// The fields of a struct embedded with a nil pointer are not encoded.
+		if !structField.value.IsValid() {
+			continue
+		}
+
+		// Omitted fields are not part of the encoding, and are thus not constrained.
+		if jsonTag := structField.jsonTag; jsonTag != nil &&
+			((jsonTag.OmitEmpty && isEmptyValue(structField.value)) ||
+				(jsonTag.OmitZero && isZeroValue(structField.value))) {
+			continue
+		}
+
+		// The value of a quoted field is described as a string rather than by its type.
+		if typeGenerationContext.IsQuoted(structField.field) {
+			continue
+		}
+
+		v.validateProperty(
+			structField.value,
+			structField.jsonschemaTag,
+			path+"/"+escapePathSegment(structField.name),
+		)
+	}
+}
+
+// Validate checks the value against the constraints of the `jsonschema` tags of its fields, with the same semantics
+// as the schema that jsonschema.Convert generates for its type: the constraints apply to the JSON encoding of the
+// value, thus omitted fields are not checked and strings are measured in code points. The fields of nested structs,
+// including those in slices, arrays and maps, are validated too.
+func Validate(value any) []types.FieldError {
+	return ValidateWithOptions(value, jsonschemaTypes.StrictOptions())
+}
+
+// ValidateWithOptions checks the value as Validate does, with the semantics of the schema that the JSON Schema producer
+// generates with the provided options.
+func ValidateWithOptions(value any, options jsonschemaTypes.Options) []types.FieldError {
+	v := validator{options: options}
+	v.validateValue(reflect.ValueOf(value), "")
+	return v.fieldErrors
+}
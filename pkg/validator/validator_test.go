@@ -0,0 +1,187 @@
+package validator_test
+
+import (
+	"slices"
+	"testing"
+
+	jsonschemaTypes "github.com/vphpersson/type_generation/pkg/producers/jsonschema/types"
+	"github.com/vphpersson/type_generation/pkg/validator"
+)
+
+type omitted struct {
+	Empty string `json:"empty,omitempty" jsonschema:"empty,minlength:3"`
+	Zero  int    `json:"zero,omitzero" jsonschema:"zero,minimum:1"`
+}
+
+type required struct {
+	Name string `json:"name" jsonschema:"name,minlength:3"`
+	Age  int    `json:"age" jsonschema:"age,minimum:1"`
+}
+
+type lengths struct {
+	Name string `json:"name" jsonschema:"name,minlength:2,maxlength:3"`
+}
+
+type bytes struct {
+	Slice []byte  `json:"slice" jsonschema:"slice,maxlength:4"`
+	Array [3]byte `json:"array" jsonschema:"array,maxitems:2"`
+}
+
+type values struct {
+	Integer int     `json:"integer" jsonschema:"integer,enum:[1,2]"`
+	Number  float64 `json:"number" jsonschema:"number,const:1.5"`
+	Boolean bool    `json:"boolean" jsonschema:"boolean,const:true"`
+}
+
+type nonEmpty struct {
+	Name  string   `json:"name"`
+	Items []string `json:"items"`
+}
+
+type Named struct {
+	Name string `json:"name" jsonschema:"name,minlength:3"`
+}
+
+type Other struct {
+	Name string `jsonschema:"name,maxlength:1"`
+}
+
+type TaggedName struct {
+	Name string `json:"Name" jsonschema:",minlength:3"`
+}
+
+type Untagged struct {
+	Name string `jsonschema:",maxlength:1"`
+}
+
+type shadowed struct {
+	Named
+	Name string `jsonschema:"name,maxlength:1"`
+}
+
+type conflicting struct {
+	Named
+	Other
+}
+
+type taggedWins struct {
+	TaggedName
+	Untagged
+}
+
+type nested struct {
+	Named
+}
+
+type shallowestWins struct {
+	nested
+	Other
+}
+
+type nilEmbedded struct {
+	*Named
+	Other
+}
+
+type unexportedEmbedded struct {
+	named
+}
+
+type named struct {
+	Name string `json:"name" jsonschema:"name,minlength:3"`
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   any
+		options jsonschemaTypes.Options
+		want    []string
+	}{
+		{name: "omitted fields", value: omitted{}},
+		{
+			name:  "included fields",
+			value: omitted{Empty: "a", Zero: -1},
+			want:  []string{"/empty minLength", "/zero minimum"},
+		},
+		{name: "required fields", value: required{}, want: []string{"/name minLength", "/age minimum"}},
+		{name: "code points", value: lengths{Name: "äöü"}},
+		{name: "code points too few", value: lengths{Name: "ä"}, want: []string{"/name minLength"}},
+		{name: "code points too many", value: lengths{Name: "äöüå"}, want: []string{"/name maxLength"}},
+		{
+			name:    "byte slice as base64",
+			value:   bytes{Slice: []byte{1, 2, 3}},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/array maxItems"},
+		},
+		{
+			name:    "byte slice as base64 too long",
+			value:   bytes{Slice: []byte{1, 2, 3, 4}},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/slice maxLength", "/array maxItems"},
+		},
+		{name: "numeric values", value: values{Integer: 2, Number: 1.5, Boolean: true}},
+		{
+			name:  "numeric values mismatch",
+			value: values{Integer: 3, Number: 1},
+			want:  []string{"/integer enum", "/number const", "/boolean const"},
+		},
+		{
+			name:    "non-empty strings and arrays",
+			value:   nonEmpty{Items: []string{}},
+			options: jsonschemaTypes.StrictOptions(),
+			want:    []string{"/name minLength", "/items minItems"},
+		},
+		{
+			name:    "lenient strings and arrays",
+			value:   nonEmpty{Items: []string{}},
+			options: jsonschemaTypes.LenientOptions(),
+		},
+		{
+			name:    "outer field shadows embedded field",
+			value:   shadowed{Named: Named{Name: "a"}, Name: "ab"},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/name maxLength"},
+		},
+		{
+			name:    "conflicting embedded fields are dropped",
+			value:   conflicting{Named: Named{Name: "ab"}, Other: Other{Name: "ab"}},
+			options: jsonschemaTypes.LenientOptions(),
+		},
+		{
+			name:    "tagged embedded field wins",
+			value:   taggedWins{TaggedName: TaggedName{Name: "ab"}, Untagged: Untagged{Name: "ab"}},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/Name minLength"},
+		},
+		{
+			name:    "shallowest embedded field wins",
+			value:   shallowestWins{nested: nested{Named: Named{Name: "ab"}}, Other: Other{Name: "ab"}},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/name maxLength"},
+		},
+		{
+			name:    "nil embedded struct conflicts",
+			value:   nilEmbedded{Other: Other{Name: "ab"}},
+			options: jsonschemaTypes.LenientOptions(),
+		},
+		{
+			name:    "unexported embedded struct",
+			value:   unexportedEmbedded{named: named{Name: "ab"}},
+			options: jsonschemaTypes.LenientOptions(),
+			want:    []string{"/name minLength"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var got []string
+			for _, fieldError := range validator.ValidateWithOptions(testCase.value, testCase.options) {
+				got = append(got, fieldError.Path+" "+fieldError.Keyword)
+			}
+			if !slices.Equal(got, testCase.want) {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}